@@ -0,0 +1,251 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AsyncPolicy decides what happens to a record when the async queue is full
+type AsyncPolicy string
+
+const (
+	// AsyncBlock waits for room in the queue
+	AsyncBlock AsyncPolicy = "block"
+	// AsyncDropNewest drops the record being logged
+	AsyncDropNewest AsyncPolicy = "drop_newest"
+	// AsyncDropOldest drops the oldest queued record to make room
+	AsyncDropOldest AsyncPolicy = "drop_oldest"
+)
+
+// defaultAsyncQueueSize is used when AsyncConfig.QueueSize is not positive
+const defaultAsyncQueueSize = 1024
+
+var (
+	queueDepthOpts = prometheus.GaugeOpts{
+		Name: "log_queue_depth",
+		Help: "Number of log records waiting to be written by the async handler",
+	}
+	droppedRecordsOpts = prometheus.CounterOpts{
+		Name: "log_records_dropped_total",
+		Help: "Number of log records dropped because the async queue was full",
+	}
+)
+
+// AsyncConfig moves formatting and writing of records off the calling goroutine
+type AsyncConfig struct {
+	Enabled bool
+	// QueueSize is the number of records buffered; zero means 1024
+	QueueSize int
+	// Policy applies when the queue is full; empty means AsyncBlock
+	Policy AsyncPolicy
+}
+
+// asyncEntry is a queued record together with the handler and context it was logged with
+type asyncEntry struct {
+	ctx     context.Context
+	record  slog.Record
+	handler slog.Handler
+}
+
+// asyncQueue is a bounded ring buffer drained by a single goroutine
+type asyncQueue struct {
+	policy  AsyncPolicy
+	depth   prometheus.Gauge
+	dropped *prometheus.CounterVec
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	entries  []asyncEntry
+	head     int
+	count    int
+	closed   bool
+
+	done chan struct{}
+}
+
+func newAsyncQueue(config AsyncConfig, depth prometheus.Gauge, dropped *prometheus.CounterVec) *asyncQueue {
+	size := config.QueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	policy := config.Policy
+	if policy == "" {
+		policy = AsyncBlock
+	}
+
+	q := &asyncQueue{
+		policy:  policy,
+		depth:   depth,
+		dropped: dropped,
+		entries: make([]asyncEntry, size),
+		done:    make(chan struct{}),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+
+	go q.run()
+	return q
+}
+
+// push queues entry and reports false when the queue is closed and the caller
+// has to handle the record itself
+func (q *asyncQueue) push(entry asyncEntry) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.count == len(q.entries) && !q.closed {
+		switch q.policy {
+		case AsyncDropNewest:
+			q.drop(entry.record.Level)
+			return true
+		case AsyncDropOldest:
+			q.drop(q.entries[q.head].record.Level)
+			q.entries[q.head] = asyncEntry{}
+			q.head = (q.head + 1) % len(q.entries)
+			q.count--
+		default:
+			q.notFull.Wait()
+		}
+	}
+	if q.closed {
+		return false
+	}
+
+	q.entries[(q.head+q.count)%len(q.entries)] = entry
+	q.count++
+	q.setDepth()
+	q.notEmpty.Signal()
+	return true
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	for {
+		q.mu.Lock()
+		for q.count == 0 && !q.closed {
+			q.notEmpty.Wait()
+		}
+		if q.count == 0 {
+			q.mu.Unlock()
+			return
+		}
+		entry := q.entries[q.head]
+		q.entries[q.head] = asyncEntry{}
+		q.head = (q.head + 1) % len(q.entries)
+		q.count--
+		q.setDepth()
+		q.notFull.Signal()
+		q.mu.Unlock()
+
+		_ = entry.handler.Handle(entry.ctx, entry.record)
+	}
+}
+
+// stop writes the queued records and stops the background goroutine. Records
+// logged afterwards are written synchronously.
+func (q *asyncQueue) stop(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *asyncQueue) drop(level slog.Level) {
+	if q.dropped != nil {
+		q.dropped.WithLabelValues(level.String()).Inc()
+	}
+}
+
+func (q *asyncQueue) setDepth() {
+	if q.depth != nil {
+		q.depth.Set(float64(q.count))
+	}
+}
+
+// asyncHandler hands records to a background goroutine. The context is kept
+// without its cancellation so that trace fields and context attributes captured
+// at call time are still available when the record is written. Attribute values
+// are snapshotted at call time as well, since the caller may change them before
+// the record is written.
+type asyncHandler struct {
+	slog.Handler
+	queue *asyncQueue
+}
+
+func (h *asyncHandler) Handle(ctx context.Context, record slog.Record) error {
+	queued := context.WithoutCancel(ctx)
+	if attrs := ContextAttrs(ctx); len(attrs) > 0 {
+		queued = context.WithValue(queued, contextAttrsKey{}, snapshotAttrs(attrs))
+	}
+	snapshot := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		snapshot.AddAttrs(snapshotAttr(attr))
+		return true
+	})
+
+	entry := asyncEntry{ctx: queued, record: snapshot, handler: h.Handler}
+	if !h.queue.push(entry) {
+		return h.Handler.Handle(ctx, record)
+	}
+	return nil
+}
+
+func (h *asyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &asyncHandler{Handler: h.Handler.WithAttrs(attrs), queue: h.queue}
+}
+
+func (h *asyncHandler) WithGroup(name string) slog.Handler {
+	return &asyncHandler{Handler: h.Handler.WithGroup(name), queue: h.queue}
+}
+
+func snapshotAttrs(attrs []slog.Attr) []slog.Attr {
+	snapshot := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		snapshot[i] = snapshotAttr(attr)
+	}
+	return snapshot
+}
+
+// snapshotAttr resolves LogValuers and copies maps, slices and pointed-to values,
+// which the caller can change after logging, the way the memory buffer does.
+// Errors are kept so that handlers still render them as errors.
+func snapshotAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		attr.Value = slog.GroupValue(snapshotAttrs(attr.Value.Group())...)
+	case slog.KindAny:
+		v := attr.Value.Any()
+		if _, ok := v.(error); ok {
+			return attr
+		}
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Slice:
+			if b, ok := v.([]byte); ok {
+				attr.Value = slog.AnyValue(bytes.Clone(b))
+			} else {
+				attr.Value = slog.AnyValue(jsonCopy(v))
+			}
+		case reflect.Map, reflect.Pointer:
+			if !rv.IsNil() {
+				attr.Value = slog.AnyValue(jsonCopy(v))
+			}
+		}
+	}
+	return attr
+}
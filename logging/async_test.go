@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// gatedWriter blocks writes until the gate is opened
+type gatedWriter struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func newTestAsyncLogger(w *gatedWriter, config AsyncConfig, dropped *prometheus.CounterVec) (*slog.Logger, *asyncQueue) {
+	queue := newAsyncQueue(config, nil, dropped)
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	return slog.New(&asyncHandler{Handler: handler, queue: queue}), queue
+}
+
+// fillQueue logs n records once the worker is blocked writing the first one
+func fillQueue(t *testing.T, logger *slog.Logger, queue *asyncQueue, n int) {
+	t.Helper()
+	logger.Info("first")
+	for {
+		queue.mu.Lock()
+		empty := queue.count == 0
+		queue.mu.Unlock()
+		if empty {
+			break
+		}
+		runtime.Gosched()
+	}
+	for i := 0; i < n; i++ {
+		logger.Info("queued", "i", i)
+	}
+}
+
+func TestAsyncFlushOnStop(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	logger, queue := newTestAsyncLogger(w, AsyncConfig{QueueSize: 8}, nil)
+
+	fillQueue(t, logger, queue, 5)
+	close(w.gate)
+	if err := queue.stop(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := strings.Count(w.String(), "\n"); got != 6 {
+		t.Errorf("Expected 6 records after flush, got %d: %s", got, w.String())
+	}
+
+	logger.Info("after stop")
+	if !strings.Contains(w.String(), "after stop") {
+		t.Error("Expected records after stop to be written synchronously")
+	}
+}
+
+func TestAsyncDropNewest(t *testing.T) {
+	dropped := prometheus.NewCounterVec(droppedRecordsOpts, []string{"level"})
+	w := &gatedWriter{gate: make(chan struct{})}
+	logger, queue := newTestAsyncLogger(w, AsyncConfig{QueueSize: 2, Policy: AsyncDropNewest}, dropped)
+
+	fillQueue(t, logger, queue, 5)
+	close(w.gate)
+	_ = queue.stop(context.Background())
+
+	output := w.String()
+	if !strings.Contains(output, "i=0") || !strings.Contains(output, "i=1") || strings.Contains(output, "i=4") {
+		t.Errorf("Expected the oldest records to be kept, got %s", output)
+	}
+	if got := testutil.ToFloat64(dropped.WithLabelValues("INFO")); got != 3 {
+		t.Errorf("Expected 3 dropped records, got %v", got)
+	}
+}
+
+func TestAsyncDropOldest(t *testing.T) {
+	dropped := prometheus.NewCounterVec(droppedRecordsOpts, []string{"level"})
+	w := &gatedWriter{gate: make(chan struct{})}
+	logger, queue := newTestAsyncLogger(w, AsyncConfig{QueueSize: 2, Policy: AsyncDropOldest}, dropped)
+
+	fillQueue(t, logger, queue, 5)
+	close(w.gate)
+	_ = queue.stop(context.Background())
+
+	output := w.String()
+	if strings.Contains(output, "i=0") || !strings.Contains(output, "i=3") || !strings.Contains(output, "i=4") {
+		t.Errorf("Expected the newest records to be kept, got %s", output)
+	}
+	if got := testutil.ToFloat64(dropped.WithLabelValues("INFO")); got != 3 {
+		t.Errorf("Expected 3 dropped records, got %v", got)
+	}
+}
+
+func TestAsyncPreservesContext(t *testing.T) {
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	err := ConfigureLogging(LoggingConfig{
+		Level:   slog.LevelInfo,
+		AsJSON:  true,
+		Tracing: true,
+		Writer:  &buf,
+		Async:   AsyncConfig{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(WithAttrs(testSpanContext(t), slog.String("tenant", "acme")))
+	GetLogger("async").InfoContext(ctx, "queued")
+	cancel()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	record := decodeRecord(t, &buf)
+	span, ok := record["span"].(map[string]any)
+	if !ok || span["trace_id"] != trace.SpanContextFromContext(ctx).TraceID().String() {
+		t.Errorf("Expected trace fields from the call site, got %v", record)
+	}
+	if record["tenant"] != "acme" {
+		t.Errorf("Expected context attributes from the call site, got %v", record)
+	}
+}
+
+// countingValuer counts how often it is resolved
+type countingValuer struct {
+	calls *int
+}
+
+func (v countingValuer) LogValue() slog.Value {
+	*v.calls++
+	return slog.IntValue(*v.calls)
+}
+
+func TestAsyncSnapshotsAttributes(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	logger, queue := newTestAsyncLogger(w, AsyncConfig{QueueSize: 8}, nil)
+
+	fillQueue(t, logger, queue, 0)
+	tags := map[string]string{"env": "prod"}
+	ids := []int{1, 2}
+	calls := 0
+	logger.Info("queued", "tags", tags, "ids", ids, slog.Group("req", "resolved", countingValuer{calls: &calls}))
+	// Run under -race, changing the values while the record is queued must not race
+	tags["env"] = "dev"
+	ids[0] = 3
+	calls = 10
+
+	close(w.gate)
+	if err := queue.stop(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	out := w.String()
+	if !strings.Contains(out, "tags=map[env:prod]") || !strings.Contains(out, `ids="[1 2]"`) {
+		t.Errorf("Expected values as logged, got %s", out)
+	}
+	if !strings.Contains(out, "req.resolved=1") {
+		t.Errorf("Expected LogValuer to be resolved when logged, got %s", out)
+	}
+}
+
+func TestAsyncUnsupportedPolicy(t *testing.T) {
+	err := ConfigureLogging(LoggingConfig{Writer: &bytes.Buffer{}, Async: AsyncConfig{Enabled: true, Policy: "spill"}})
+	if err == nil {
+		t.Error("Expected error for unsupported policy")
+	}
+}
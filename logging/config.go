@@ -2,46 +2,211 @@
 package logging
 
 import (
+	"io"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
 )
 
 // LoggingConfig holds configuration for structured logging
 type LoggingConfig struct {
-	Level   slog.Level
+	Level slog.Level
+	// Format selects the record layout; empty means json or text depending on AsJSON
+	Format  Format
 	AsJSON  bool
 	Tracing bool
+	// TraceFields controls the trace field layout of the text and json formats
+	TraceFields TraceFieldsConfig
+	// GCPProjectID qualifies trace IDs as projects/<id>/traces/<trace_id> in the gcp format
+	GCPProjectID string
+	// LoggerLevels overrides Level for loggers created with GetLogger, keyed by name
+	LoggerLevels map[string]slog.Level
+	// OTLP additionally exports records to an OpenTelemetry collector when ExportType is set
+	OTLP OTLPConfig
+	// Output is "stdout", "stderr" or a file path; empty means stdout
+	Output string
+	// Writer overrides Output with an arbitrary destination
+	Writer io.Writer
+	// File controls rotation when Output is a file path
+	File FileConfig
+	// Sinks are additional destinations, each with its own level and format
+	Sinks []SinkConfig
+	// Memory keeps the most recent records in memory for the /debug/logs endpoint
+	Memory MemoryConfig
+	// Baggage copies OpenTelemetry baggage members from the context into a "baggage" group
+	Baggage bool
+	// Errors renders error values with their chain and, optionally, a stack trace
+	Errors ErrorConfig
+	// Redact masks sensitive data before it reaches any sink
+	Redact RedactConfig
+	// Sampling drops bursts of similar records
+	Sampling SamplingConfig
+	// Async writes records from a background goroutine through a bounded queue
+	Async AsyncConfig
+	// CountRecords counts records in log_records_total{level,logger}; ConfigureLogging
+	// fails when it is set without Metrics
+	CountRecords bool
+	// Metrics receives logging metrics such as record and suppressed record counts when set
+	Metrics *metrics.MetricsCollector
+}
+
+// SinkConfig describes an additional log destination. Unlike the primary output,
+// a sink uses its own fixed Level and ignores runtime and per-logger level changes.
+type SinkConfig struct {
+	Level slog.Level
+	// Format selects the record layout; empty means json or text depending on AsJSON
+	Format       Format
+	AsJSON       bool
+	Tracing      bool
+	TraceFields  TraceFieldsConfig
+	GCPProjectID string
+	// Output is "stdout", "stderr" or a file path; empty means stdout
+	Output string
+	// Writer overrides Output with an arbitrary destination
+	Writer io.Writer
+	// File controls rotation when Output is a file path
+	File FileConfig
+}
+
+// primarySink describes the output configured by the top-level fields
+func (c LoggingConfig) primarySink() SinkConfig {
+	return SinkConfig{
+		Level:        c.Level,
+		Format:       c.Format,
+		AsJSON:       c.AsJSON,
+		Tracing:      c.Tracing,
+		TraceFields:  c.TraceFields,
+		GCPProjectID: c.GCPProjectID,
+		Output:       c.Output,
+		Writer:       c.Writer,
+		File:         c.File,
+	}
 }
 
 // FromEnv creates LoggingConfig from environment variables
 func FromEnv() LoggingConfig {
+	output := getEnvOrDefault("LOG_OUTPUT", OutputStdout)
+
 	return LoggingConfig{
 		Level:   parseLogLevel(getEnvOrDefault("LOG_LEVEL", "INFO")),
+		Format:  detectFormat(output),
 		AsJSON:  parseBool(getEnvOrDefault("LOG_AS_JSON", "false")),
 		Tracing: parseBool(getEnvOrDefault("LOG_TRACING", "false")),
+		TraceFields: TraceFieldsConfig{
+			Layout:         TraceLayout(getEnvOrDefault("LOG_TRACE_LAYOUT", string(TraceLayoutNested))),
+			Flags:          parseBool(getEnvOrDefault("LOG_TRACE_FLAGS", "false")),
+			GroupKey:       getEnvOrDefault("LOG_TRACE_GROUP_KEY", ""),
+			TraceIDKey:     getEnvOrDefault("LOG_TRACE_ID_KEY", ""),
+			SpanIDKey:      getEnvOrDefault("LOG_SPAN_ID_KEY", ""),
+			TraceparentKey: getEnvOrDefault("LOG_TRACEPARENT_KEY", ""),
+		},
+		GCPProjectID: getEnvOrDefault("LOG_GCP_PROJECT_ID", os.Getenv("GOOGLE_CLOUD_PROJECT")),
+		LoggerLevels: parseLoggerLevels(getEnvOrDefault("LOG_LEVELS", "")),
+		OTLP: OTLPConfig{
+			ExportType: OTLPExportType(getEnvOrDefault("LOG_OTLP_EXPORTER_TYPE", "")),
+			Endpoint:   getEnvOrDefault("LOG_OTLP_EXPORTER_ENDPOINT", ""),
+		},
+		Output: output,
+		File: FileConfig{
+			MaxSize:        parseInt64(getEnvOrDefault("LOG_FILE_MAX_SIZE_MB", "100")) * 1024 * 1024,
+			RotateInterval: parseDuration(getEnvOrDefault("LOG_FILE_ROTATE_INTERVAL", "0")),
+			MaxBackups:     int(parseInt64(getEnvOrDefault("LOG_FILE_MAX_BACKUPS", "7"))),
+			Compress:       parseBool(getEnvOrDefault("LOG_FILE_COMPRESS", "false")),
+			ReopenOnSIGHUP: parseBool(getEnvOrDefault("LOG_FILE_REOPEN_ON_SIGHUP", "false")),
+		},
+		Memory: MemoryConfig{
+			Enabled: parseBool(getEnvOrDefault("LOG_MEMORY_BUFFER", "false")),
+			Size:    int(parseInt64(getEnvOrDefault("LOG_MEMORY_BUFFER_SIZE", "500"))),
+		},
+		Baggage: parseBool(getEnvOrDefault("LOG_BAGGAGE", "false")),
+		Errors: ErrorConfig{
+			Enabled: parseBool(getEnvOrDefault("LOG_ERROR_DETAILS", "false")),
+			Stack:   parseBool(getEnvOrDefault("LOG_ERROR_STACK", "false")),
+		},
+		Redact: RedactConfig{
+			Enabled: parseBool(getEnvOrDefault("LOG_REDACT", "false")),
+			Keys:    parseList(getEnvOrDefault("LOG_REDACT_KEYS", "")),
+		},
+		CountRecords: parseBool(getEnvOrDefault("LOG_METRICS", "false")),
+		Async: AsyncConfig{
+			Enabled:   parseBool(getEnvOrDefault("LOG_ASYNC", "false")),
+			QueueSize: int(parseInt64(getEnvOrDefault("LOG_ASYNC_QUEUE_SIZE", "1024"))),
+			Policy:    AsyncPolicy(getEnvOrDefault("LOG_ASYNC_POLICY", string(AsyncBlock))),
+		},
+		Sampling: SamplingConfig{
+			Enabled:  parseBool(getEnvOrDefault("LOG_SAMPLING", "false")),
+			Interval: parseDuration(getEnvOrDefault("LOG_SAMPLING_INTERVAL", "1s")),
+			SamplingLimits: SamplingLimits{
+				First:      int(parseInt64(getEnvOrDefault("LOG_SAMPLING_FIRST", "100"))),
+				Thereafter: int(parseInt64(getEnvOrDefault("LOG_SAMPLING_THEREAFTER", "100"))),
+			},
+		},
+	}
+}
+
+// parseLoggerLevels parses "db=debug,http=warn", skipping malformed entries
+func parseLoggerLevels(value string) map[string]slog.Level {
+	levels := map[string]slog.Level{}
+	for _, entry := range strings.Split(value, ",") {
+		name, levelStr, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		level, err := ParseLevel(strings.TrimSpace(levelStr))
+		if err != nil {
+			continue
+		}
+		levels[name] = level
 	}
+	return levels
 }
 
 func parseLogLevel(level string) slog.Level {
-	switch strings.ToUpper(level) {
-	case "DEBUG":
-		return slog.LevelDebug
-	case "INFO":
-		return slog.LevelInfo
-	case "WARN", "WARNING":
-		return slog.LevelWarn
-	case "ERROR":
-		return slog.LevelError
-	default:
+	parsed, err := ParseLevel(level)
+	if err != nil {
 		return slog.LevelInfo
 	}
+	return parsed
 }
 
 func parseBool(value string) bool {
 	return strings.ToLower(value) == "true" || strings.ToLower(value) == "t"
 }
 
+// parseList parses a comma-separated list, dropping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseInt64 parses a non-negative integer, treating invalid values as zero
+func parseInt64(value string) int64 {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0
+	}
+	return parsed
+}
+
+// parseDuration parses a non-negative duration, treating invalid values as zero
+func parseDuration(value string) time.Duration {
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		return 0
+	}
+	return parsed
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
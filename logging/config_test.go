@@ -3,7 +3,9 @@ package logging
 import (
 	"log/slog"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFromEnv(t *testing.T) {
@@ -19,6 +21,12 @@ func TestFromEnv(t *testing.T) {
 	if config.Tracing != false {
 		t.Errorf("Expected Tracing to be false, got %v", config.Tracing)
 	}
+	if config.OTLP.ExportType != "" {
+		t.Errorf("Expected OTLP export to be disabled, got %s", config.OTLP.ExportType)
+	}
+	if config.Output != OutputStdout {
+		t.Errorf("Expected Output to be 'stdout', got %s", config.Output)
+	}
 }
 
 func TestFromEnvWithValues(t *testing.T) {
@@ -26,10 +34,14 @@ func TestFromEnvWithValues(t *testing.T) {
 	os.Setenv("LOG_LEVEL", "DEBUG")
 	os.Setenv("LOG_AS_JSON", "true")
 	os.Setenv("LOG_TRACING", "true")
+	os.Setenv("LOG_OTLP_EXPORTER_TYPE", "grpc")
+	os.Setenv("LOG_OTLP_EXPORTER_ENDPOINT", "localhost:4317")
 	defer func() {
 		os.Unsetenv("LOG_LEVEL")
 		os.Unsetenv("LOG_AS_JSON")
 		os.Unsetenv("LOG_TRACING")
+		os.Unsetenv("LOG_OTLP_EXPORTER_TYPE")
+		os.Unsetenv("LOG_OTLP_EXPORTER_ENDPOINT")
 	}()
 
 	config := FromEnv()
@@ -43,6 +55,12 @@ func TestFromEnvWithValues(t *testing.T) {
 	if config.Tracing != true {
 		t.Errorf("Expected Tracing to be true, got %v", config.Tracing)
 	}
+	if config.OTLP.ExportType != OTLPExportGRPC {
+		t.Errorf("Expected OTLP ExportType to be 'grpc', got %s", config.OTLP.ExportType)
+	}
+	if config.OTLP.Endpoint != "localhost:4317" {
+		t.Errorf("Expected OTLP Endpoint to be 'localhost:4317', got %s", config.OTLP.Endpoint)
+	}
 }
 
 func TestParseLogLevel(t *testing.T) {
@@ -91,3 +109,102 @@ func TestParseBool(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLoggerLevels(t *testing.T) {
+	levels := parseLoggerLevels("db=debug, http=WARN,broken,=info,bad=loud")
+
+	expected := map[string]slog.Level{
+		"db":   slog.LevelDebug,
+		"http": slog.LevelWarn,
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Errorf("parseLoggerLevels() = %v, expected %v", levels, expected)
+	}
+}
+
+func TestFromEnvWithFileOutput(t *testing.T) {
+	os.Setenv("LOG_OUTPUT", "/var/log/app.log")
+	os.Setenv("LOG_FILE_MAX_SIZE_MB", "10")
+	os.Setenv("LOG_FILE_ROTATE_INTERVAL", "24h")
+	os.Setenv("LOG_FILE_MAX_BACKUPS", "3")
+	os.Setenv("LOG_FILE_COMPRESS", "true")
+	os.Setenv("LOG_FILE_REOPEN_ON_SIGHUP", "true")
+	defer func() {
+		os.Unsetenv("LOG_OUTPUT")
+		os.Unsetenv("LOG_FILE_MAX_SIZE_MB")
+		os.Unsetenv("LOG_FILE_ROTATE_INTERVAL")
+		os.Unsetenv("LOG_FILE_MAX_BACKUPS")
+		os.Unsetenv("LOG_FILE_COMPRESS")
+		os.Unsetenv("LOG_FILE_REOPEN_ON_SIGHUP")
+	}()
+
+	config := FromEnv()
+
+	expected := FileConfig{
+		MaxSize:        10 * 1024 * 1024,
+		RotateInterval: 24 * time.Hour,
+		MaxBackups:     3,
+		Compress:       true,
+		ReopenOnSIGHUP: true,
+	}
+	if config.Output != "/var/log/app.log" {
+		t.Errorf("Expected Output to be '/var/log/app.log', got %s", config.Output)
+	}
+	if config.File != expected {
+		t.Errorf("Expected File to be %+v, got %+v", expected, config.File)
+	}
+}
+
+func TestFromEnvWithRedact(t *testing.T) {
+	os.Setenv("LOG_REDACT", "true")
+	os.Setenv("LOG_REDACT_KEYS", "password, ssn,")
+	defer func() {
+		os.Unsetenv("LOG_REDACT")
+		os.Unsetenv("LOG_REDACT_KEYS")
+	}()
+
+	config := FromEnv()
+
+	if !config.Redact.Enabled {
+		t.Error("Expected Redact to be enabled")
+	}
+	if !reflect.DeepEqual(config.Redact.Keys, []string{"password", "ssn"}) {
+		t.Errorf("Expected Redact keys to be [password ssn], got %v", config.Redact.Keys)
+	}
+}
+
+func TestFromEnvWithFormat(t *testing.T) {
+	os.Setenv("LOG_FORMAT", "gcp")
+	os.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	defer func() {
+		os.Unsetenv("LOG_FORMAT")
+		os.Unsetenv("GOOGLE_CLOUD_PROJECT")
+	}()
+
+	config := FromEnv()
+
+	if config.Format != FormatGCP {
+		t.Errorf("Expected Format to be gcp, got %s", config.Format)
+	}
+	if config.GCPProjectID != "my-project" {
+		t.Errorf("Expected GCPProjectID to be my-project, got %s", config.GCPProjectID)
+	}
+}
+
+func TestFromEnvWithTraceFields(t *testing.T) {
+	os.Setenv("LOG_TRACE_LAYOUT", "flat")
+	os.Setenv("LOG_TRACE_FLAGS", "true")
+	os.Setenv("LOG_TRACE_ID_KEY", "dd.trace_id")
+	defer func() {
+		os.Unsetenv("LOG_TRACE_LAYOUT")
+		os.Unsetenv("LOG_TRACE_FLAGS")
+		os.Unsetenv("LOG_TRACE_ID_KEY")
+	}()
+
+	config := FromEnv()
+
+	expected := TraceFieldsConfig{Layout: TraceLayoutFlat, Flags: true, TraceIDKey: "dd.trace_id"}
+	if config.TraceFields != expected {
+		t.Errorf("Expected TraceFields to be %+v, got %+v", expected, config.TraceFields)
+	}
+}
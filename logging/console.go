@@ -0,0 +1,356 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// consoleTimeFormat is short since console output is read as it is written
+	consoleTimeFormat = "15:04:05.000"
+	// consoleMessageWidth aligns the attributes following short messages
+	consoleMessageWidth = 40
+	consoleIndent       = "    "
+)
+
+// ANSI escape sequences used by ConsoleHandler
+const (
+	ansiReset   = "\x1b[0m"
+	ansiDim     = "\x1b[2m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// ConsoleHandlerOptions configures a ConsoleHandler
+type ConsoleHandlerOptions struct {
+	// Level is the minimum level logged; nil means INFO
+	Level slog.Leveler
+	// AddSource appends the caller's file and line, relative to the working directory
+	AddSource bool
+	// NoColor disables ANSI colors
+	NoColor bool
+}
+
+// ConsoleHandler writes human-friendly, colored records for local development.
+// Each record is one aligned line of time, level, logger, message and attributes,
+// followed by indented blocks for groups, errors and multi-line strings.
+type ConsoleHandler struct {
+	opts   ConsoleHandlerOptions
+	w      io.Writer
+	mu     *sync.Mutex
+	cwd    string
+	logger string
+	goas   []slogGroupOrAttrs
+}
+
+// NewConsoleHandler creates a ConsoleHandler writing to w
+func NewConsoleHandler(w io.Writer, opts *ConsoleHandlerOptions) *ConsoleHandler {
+	h := &ConsoleHandler{w: w, mu: &sync.Mutex{}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	h.cwd, _ = os.Getwd()
+	return h
+}
+
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	threshold := slog.LevelInfo
+	if h.opts.Level != nil {
+		threshold = h.opts.Level.Level()
+	}
+	return level >= threshold
+}
+
+func (h *ConsoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+
+	if !record.Time.IsZero() {
+		h.colored(&buf, ansiDim, record.Time.Format(consoleTimeFormat))
+		buf.WriteByte(' ')
+	}
+	h.colored(&buf, levelColor(record.Level), fmt.Sprintf("%-5s", record.Level.String()))
+	buf.WriteByte(' ')
+	if h.logger != "" {
+		h.colored(&buf, ansiCyan, "["+h.logger+"]")
+		buf.WriteByte(' ')
+	}
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	attrs = nestAttrs(h.goas, attrs)
+
+	var inline, blocks []slog.Attr
+	for _, attr := range flattenAttrs(attrs) {
+		if isBlockAttr(attr) {
+			blocks = append(blocks, attr)
+		} else {
+			inline = append(inline, attr)
+		}
+	}
+
+	message := record.Message
+	if len(inline) > 0 || h.opts.AddSource {
+		message = fmt.Sprintf("%-*s", consoleMessageWidth, message)
+	}
+	h.colored(&buf, ansiBold, message)
+
+	for _, attr := range inline {
+		buf.WriteByte(' ')
+		h.writeInlineAttr(&buf, "", attr)
+	}
+
+	if h.opts.AddSource && record.PC != 0 {
+		buf.WriteByte(' ')
+		h.colored(&buf, ansiDim, h.source(record))
+	}
+	buf.WriteByte('\n')
+
+	for _, attr := range blocks {
+		h.writeBlockAttr(&buf, consoleIndent, attr)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	if !slices.ContainsFunc(h.goas, func(goa slogGroupOrAttrs) bool { return goa.group != "" }) {
+		// The logger name is shown in its own column instead of as an attribute,
+		// even when top-level attributes such as trace fields came before it
+		rest := make([]slog.Attr, 0, len(attrs))
+		for _, attr := range attrs {
+			if attr.Key == loggerKey && attr.Value.Kind() == slog.KindString {
+				h2.logger = attr.Value.String()
+				continue
+			}
+			rest = append(rest, attr)
+		}
+		attrs = rest
+		if len(attrs) == 0 {
+			return &h2
+		}
+	}
+	h2.goas = append(slices.Clip(h.goas), slogGroupOrAttrs{attrs: attrs})
+	return &h2
+}
+
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.goas = append(slices.Clip(h.goas), slogGroupOrAttrs{group: name})
+	return &h2
+}
+
+func (h *ConsoleHandler) writeInlineAttr(buf *bytes.Buffer, prefix string, attr slog.Attr) {
+	h.colored(buf, ansiDim, prefix+attr.Key+"=")
+	buf.WriteString(consoleValue(attr.Value))
+}
+
+func (h *ConsoleHandler) writeBlockAttr(buf *bytes.Buffer, indent string, attr slog.Attr) {
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		buf.WriteString(indent)
+		h.colored(buf, ansiDim, attr.Key+":")
+		buf.WriteByte('\n')
+		for _, member := range flattenAttrs(attr.Value.Group()) {
+			if isBlockAttr(member) {
+				h.writeBlockAttr(buf, indent+"  ", member)
+				continue
+			}
+			buf.WriteString(indent + "  ")
+			h.writeInlineAttr(buf, "", member)
+			buf.WriteByte('\n')
+		}
+	case slog.KindString:
+		buf.WriteString(indent)
+		h.colored(buf, ansiDim, attr.Key+":")
+		buf.WriteByte('\n')
+		for _, line := range strings.Split(strings.TrimRight(attr.Value.String(), "\n"), "\n") {
+			h.colored(buf, ansiDim, indent+"  | ")
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	default:
+		err := attr.Value.Any().(error)
+		buf.WriteString(indent)
+		h.colored(buf, ansiRed, attr.Key+": "+err.Error())
+		buf.WriteByte('\n')
+		h.writeCauses(buf, indent+"  ", err)
+	}
+}
+
+// writeCauses lists the wrapped errors of err, one per line
+func (h *ConsoleHandler) writeCauses(buf *bytes.Buffer, indent string, err error) {
+	var causes []error
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapped.Unwrap(); cause != nil {
+			causes = []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		causes = wrapped.Unwrap()
+	}
+
+	for _, cause := range causes {
+		buf.WriteString(indent)
+		h.colored(buf, ansiDim, "caused by: ")
+		buf.WriteString(cause.Error())
+		buf.WriteByte('\n')
+		h.writeCauses(buf, indent+"  ", cause)
+	}
+}
+
+// source returns the caller as a path relative to the working directory, or as
+// the last directory and file name when the file is outside of it
+func (h *ConsoleHandler) source(record slog.Record) string {
+	frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+	if frame.File == "" {
+		return ""
+	}
+
+	file := frame.File
+	if rel, err := filepath.Rel(h.cwd, file); err == nil && h.cwd != "" && !strings.HasPrefix(rel, "..") {
+		file = rel
+	} else {
+		file = filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file))
+	}
+	return file + ":" + strconv.Itoa(frame.Line)
+}
+
+func (h *ConsoleHandler) colored(buf *bytes.Buffer, color, s string) {
+	if h.opts.NoColor {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteString(color)
+	buf.WriteString(s)
+	buf.WriteString(ansiReset)
+}
+
+func levelColor(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return ansiMagenta
+	case level < slog.LevelWarn:
+		return ansiGreen
+	case level < slog.LevelError:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+// flattenAttrs resolves values, drops empty attributes and inlines groups without a key
+func flattenAttrs(attrs []slog.Attr) []slog.Attr {
+	flat := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		if attr.Equal(slog.Attr{}) {
+			continue
+		}
+		if attr.Value.Kind() == slog.KindGroup {
+			if len(attr.Value.Group()) == 0 {
+				continue
+			}
+			if attr.Key == "" {
+				flat = append(flat, flattenAttrs(attr.Value.Group())...)
+				continue
+			}
+		}
+		flat = append(flat, attr)
+	}
+	return flat
+}
+
+// isBlockAttr reports whether attr is rendered on its own lines below the record
+func isBlockAttr(attr slog.Attr) bool {
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		return true
+	case slog.KindString:
+		return strings.Contains(attr.Value.String(), "\n")
+	case slog.KindAny:
+		_, ok := attr.Value.Any().(error)
+		return ok
+	}
+	return false
+}
+
+func consoleValue(value slog.Value) string {
+	var s string
+	switch value.Kind() {
+	case slog.KindTime:
+		s = value.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		s = value.Duration().String()
+	default:
+		s = value.String()
+	}
+	if s == "" || strings.IndexFunc(s, needsQuote) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuote(r rune) bool {
+	return unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r)
+}
+
+// isTerminal reports whether f is connected to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// detectFormat picks the console format when output is an interactive terminal
+// and neither LOG_FORMAT nor LOG_AS_JSON is set
+func detectFormat(output string) Format {
+	if format := getEnvOrDefault("LOG_FORMAT", ""); format != "" {
+		return Format(format)
+	}
+	if _, ok := os.LookupEnv("LOG_AS_JSON"); ok {
+		return ""
+	}
+
+	switch strings.ToLower(output) {
+	case "", OutputStdout:
+		if isTerminal(os.Stdout) {
+			return FormatConsole
+		}
+	case OutputStderr:
+		if isTerminal(os.Stderr) {
+			return FormatConsole
+		}
+	}
+	return ""
+}
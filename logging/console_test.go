@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, &ConsoleHandlerOptions{AddSource: true, NoColor: true})).
+		With(loggerKey, "db")
+
+	logger.Info("query done", "rows", 3, "sql", "select 1")
+
+	line := strings.TrimSuffix(buf.String(), "\n")
+	if strings.Contains(line, "\n") {
+		t.Fatalf("Expected a single line, got %q", buf.String())
+	}
+	for _, want := range []string{"INFO ", "[db]", "query done", "rows=3", `sql="select 1"`, "console_test.go:"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+	if strings.Contains(line, "\x1b[") {
+		t.Errorf("Expected no colors, got %q", line)
+	}
+	if strings.Contains(line, "/root/") || strings.Contains(line, "logger=") {
+		t.Errorf("Expected short source and logger column, got %q", line)
+	}
+}
+
+func TestConsoleHandlerLoggerInSpan(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := newFormatHandler(SinkConfig{Format: FormatConsole, Tracing: true}, &buf, slog.HandlerOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	slog.New(handler).With(loggerKey, "db").InfoContext(testSpanContext(t), "query done")
+
+	line := buf.String()
+	if !strings.Contains(line, "[db]") || strings.Contains(line, "logger=") {
+		t.Errorf("Expected logger column inside a span, got %q", line)
+	}
+	if !strings.Contains(line, "4bf92f3577b34da6a3ce929d0e0e4736") {
+		t.Errorf("Expected trace_id attribute, got %q", line)
+	}
+}
+
+func TestConsoleHandlerBlocks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, &ConsoleHandlerOptions{NoColor: true}))
+
+	cause := errors.New("connection refused")
+	logger.Error("request failed",
+		"err", fmt.Errorf("dial db: %w", cause),
+		slog.Group("http", "method", "GET", "status", 502),
+		"body", "line one\nline two",
+	)
+
+	output := buf.String()
+	for _, want := range []string{
+		"\n    err: dial db: connection refused\n",
+		"\n      caused by: connection refused\n",
+		"\n    http:\n      method=GET\n      status=502\n",
+		"\n    body:\n      | line one\n      | line two\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in %q", want, output)
+		}
+	}
+}
+
+func TestConsoleHandlerGroups(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, &ConsoleHandlerOptions{NoColor: true})).
+		WithGroup("req").With("id", 7)
+
+	logger.Info("handled", "ms", 12)
+
+	if !strings.Contains(buf.String(), "\n    req:\n      id=7\n      ms=12\n") {
+		t.Errorf("Expected attributes nested under req, got %q", buf.String())
+	}
+}
+
+func TestConsoleHandlerColors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, &ConsoleHandlerOptions{Level: slog.LevelDebug}))
+
+	logger.Warn("careful")
+	logger.Debug("details")
+
+	if !strings.Contains(buf.String(), ansiYellow+"WARN ") {
+		t.Errorf("Expected yellow WARN, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), ansiMagenta+"DEBUG") {
+		t.Errorf("Expected magenta DEBUG, got %q", buf.String())
+	}
+}
+
+func TestConsoleHandlerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, nil))
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected DEBUG to be dropped at the default level, got %q", buf.String())
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	os.Setenv("LOG_AS_JSON", "false")
+	defer func() {
+		os.Unsetenv("LOG_AS_JSON")
+		os.Unsetenv("LOG_FORMAT")
+	}()
+
+	// LOG_AS_JSON is set, so the terminal is not checked
+	if got := detectFormat(OutputStdout); got != "" {
+		t.Errorf("Expected no format, got %s", got)
+	}
+
+	// Test output is not a terminal
+	os.Unsetenv("LOG_AS_JSON")
+	if got := detectFormat(OutputStdout); got != "" {
+		t.Errorf("Expected no format, got %s", got)
+	}
+
+	os.Setenv("LOG_FORMAT", "ecs")
+	if got := detectFormat(OutputStdout); got != FormatECS {
+		t.Errorf("Expected ecs, got %s", got)
+	}
+}
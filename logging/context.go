@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// baggageKey groups OpenTelemetry baggage members copied into records
+const baggageKey = "baggage"
+
+type contextAttrsKey struct{}
+
+// WithAttrs returns a context carrying attrs in addition to those already on ctx.
+// They are appended to every record logged with a *Context method and that context.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	existing := ContextAttrs(ctx)
+	return context.WithValue(ctx, contextAttrsKey{}, append(slices.Clip(existing), attrs...))
+}
+
+// ContextAttrs returns the attributes stored on ctx with WithAttrs
+func ContextAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextAttrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler appends attributes stored with WithAttrs and, optionally,
+// OpenTelemetry baggage members to every record. They are kept at the top level
+// like trace fields: once a group is open, WithAttrs and WithGroup calls are
+// replayed on top of them, as layoutHandler does.
+type contextHandler struct {
+	slog.Handler
+	baggage bool
+	// base is the handler before any WithAttrs or WithGroup call, nil if there was none
+	base    slog.Handler
+	with    []func(slog.Handler) slog.Handler
+	grouped bool
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := ContextAttrs(ctx)
+	var members []slog.Attr
+	if h.baggage && ctx != nil {
+		for _, member := range baggage.FromContext(ctx).Members() {
+			members = append(members, slog.String(member.Key(), member.Value()))
+		}
+	}
+	if len(attrs) == 0 && len(members) == 0 {
+		return h.Handler.Handle(ctx, record)
+	}
+	if len(members) > 0 {
+		attrs = append(slices.Clip(attrs), slog.Attr{Key: baggageKey, Value: slog.GroupValue(members...)})
+	}
+
+	// Without an open group the record's own attributes are at the top level too
+	if !h.grouped {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+		return h.Handler.Handle(ctx, record)
+	}
+
+	handler := h.base.WithAttrs(attrs)
+	for _, with := range h.with {
+		handler = with(handler)
+	}
+	return handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.withOp(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) }, h.grouped)
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withOp(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) }, true)
+}
+
+func (h *contextHandler) withOp(with func(slog.Handler) slog.Handler, grouped bool) *contextHandler {
+	base := h.base
+	if base == nil {
+		base = h.Handler
+	}
+	return &contextHandler{
+		Handler: with(h.Handler),
+		baggage: h.baggage,
+		base:    base,
+		with:    append(slices.Clip(h.with), with),
+		grouped: grouped,
+	}
+}
@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestWithAttrs(t *testing.T) {
+	ctx := WithAttrs(context.Background(), slog.String("tenant", "acme"))
+	ctx = WithAttrs(ctx, slog.Int("user_id", 42))
+	child := WithAttrs(ctx, slog.String("step", "child"))
+
+	if attrs := ContextAttrs(ctx); len(attrs) != 2 {
+		t.Errorf("Expected 2 attributes on the parent, got %v", attrs)
+	}
+	if attrs := ContextAttrs(child); len(attrs) != 3 {
+		t.Errorf("Expected 3 attributes on the child, got %v", attrs)
+	}
+	if attrs := ContextAttrs(context.Background()); attrs != nil {
+		t.Errorf("Expected no attributes, got %v", attrs)
+	}
+}
+
+func TestContextHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(&contextHandler{Handler: slog.NewJSONHandler(&buf, nil)})
+
+	ctx := WithAttrs(context.Background(), slog.String("tenant", "acme"), slog.Int("user_id", 42))
+	logger.InfoContext(ctx, "order placed")
+
+	record := decodeRecord(t, &buf)
+	if record["tenant"] != "acme" || record["user_id"] != float64(42) {
+		t.Errorf("Expected context attributes, got %v", record)
+	}
+
+	buf.Reset()
+	logger.Info("no context")
+	if record := decodeRecord(t, &buf); record["tenant"] != nil {
+		t.Errorf("Expected no context attributes, got %v", record)
+	}
+}
+
+func TestContextHandlerInGroup(t *testing.T) {
+	member, err := baggage.NewMember("region", "eu-west-1")
+	if err != nil {
+		t.Fatalf("Failed to create baggage member: %v", err)
+	}
+	bag, err := baggage.New(member)
+	if err != nil {
+		t.Fatalf("Failed to create baggage: %v", err)
+	}
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	ctx = WithAttrs(ctx, slog.String("tenant", "acme"))
+
+	var buf bytes.Buffer
+	logger := slog.New(&contextHandler{Handler: slog.NewJSONHandler(&buf, nil), baggage: true})
+	logger.With("service", "api").WithGroup("req").With("path", "/orders").InfoContext(ctx, "handled", "status", 200)
+
+	record := decodeRecord(t, &buf)
+	if record["tenant"] != "acme" || record["service"] != "api" {
+		t.Errorf("Expected context attributes at the top level, got %s", buf.String())
+	}
+	if group, ok := record[baggageKey].(map[string]any); !ok || group["region"] != "eu-west-1" {
+		t.Errorf("Expected baggage at the top level, got %s", buf.String())
+	}
+	req, ok := record["req"].(map[string]any)
+	if !ok || req["path"] != "/orders" || req["status"] != float64(200) || req["tenant"] != nil {
+		t.Errorf("Expected only record attributes in the group, got %s", buf.String())
+	}
+}
+
+func TestContextHandlerBaggage(t *testing.T) {
+	member, err := baggage.NewMember("region", "eu-west-1")
+	if err != nil {
+		t.Fatalf("Failed to create baggage member: %v", err)
+	}
+	bag, err := baggage.New(member)
+	if err != nil {
+		t.Fatalf("Failed to create baggage: %v", err)
+	}
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	var buf bytes.Buffer
+	slog.New(&contextHandler{Handler: slog.NewJSONHandler(&buf, nil), baggage: true}).InfoContext(ctx, "with baggage")
+
+	group, ok := decodeRecord(t, &buf)[baggageKey].(map[string]any)
+	if !ok || group["region"] != "eu-west-1" {
+		t.Errorf("Expected baggage.region, got %s", buf.String())
+	}
+
+	buf.Reset()
+	slog.New(&contextHandler{Handler: slog.NewJSONHandler(&buf, nil)}).InfoContext(ctx, "without baggage")
+	if record := decodeRecord(t, &buf); record[baggageKey] != nil {
+		t.Errorf("Expected baggage to be ignored, got %v", record)
+	}
+}
+
+func TestConfigureLoggingWithContextAttrs(t *testing.T) {
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	err := ConfigureLogging(LoggingConfig{
+		Level:  slog.LevelInfo,
+		AsJSON: true,
+		Writer: &buf,
+		Redact: RedactConfig{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := WithAttrs(context.Background(), slog.String("tenant", "acme"), slog.String("token", "s3cret"))
+	GetLogger("orders").InfoContext(ctx, "order placed")
+
+	record := decodeRecord(t, &buf)
+	if record["tenant"] != "acme" {
+		t.Errorf("Expected tenant to be acme, got %v", record["tenant"])
+	}
+	if record["token"] != redactedValue {
+		t.Errorf("Expected context attributes to be redacted, got %v", record["token"])
+	}
+}
@@ -0,0 +1,224 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStackDepth bounds the number of frames captured for an error
+const maxStackDepth = 32
+
+// ErrorConfig controls how error values in records are rendered
+type ErrorConfig struct {
+	// Enabled renders errors as a group with their message, type and unwrapped chain
+	Enabled bool
+	// Stack adds the call stack to errors logged at ERROR or above
+	Stack bool
+}
+
+// errorLink describes one error of an unwrapped chain
+type errorLink struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// errorHandler renders error attributes as structured groups and, when tracing,
+// records error values logged at ERROR or above on the span in the context. It runs
+// on the calling goroutine so that the stack and span are those of the call.
+type errorHandler struct {
+	slog.Handler
+	config ErrorConfig
+	spans  bool
+	// errs are the errors added with WithAttrs, recorded on the span along
+	// with those of the record
+	errs []error
+}
+
+func (h *errorHandler) Handle(ctx context.Context, record slog.Record) error {
+	// With only span recording enabled, other records need no attribute walk
+	recordSpan := h.spans && record.Level >= slog.LevelError && trace.SpanFromContext(ctx).IsRecording()
+	if !h.config.Enabled && !recordSpan {
+		return h.Handler.Handle(ctx, record)
+	}
+
+	errs := slices.Clip(h.errs)
+	collect := func(attr slog.Attr) {
+		if err, ok := attr.Value.Any().(error); ok {
+			errs = append(errs, err)
+		}
+	}
+
+	if h.config.Enabled {
+		var stack []string
+		if h.config.Stack && record.Level >= slog.LevelError {
+			stack = captureStack(record.PC)
+		}
+
+		enriched := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+		record.Attrs(func(attr slog.Attr) bool {
+			enriched.AddAttrs(enrichErrorAttr(attr, stack, collect))
+			return true
+		})
+		record = enriched
+	} else {
+		record.Attrs(func(attr slog.Attr) bool {
+			walkErrorAttrs(attr, collect)
+			return true
+		})
+	}
+
+	if recordSpan {
+		recordSpanErrors(ctx, record.Message, errs)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *errorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	errs := slices.Clip(h.errs)
+	collect := func(attr slog.Attr) {
+		if err, ok := attr.Value.Any().(error); ok && h.spans {
+			errs = append(errs, err)
+		}
+	}
+
+	if h.config.Enabled {
+		enriched := make([]slog.Attr, len(attrs))
+		for i, attr := range attrs {
+			enriched[i] = enrichErrorAttr(attr, nil, collect)
+		}
+		attrs = enriched
+	} else if h.spans {
+		for _, attr := range attrs {
+			walkErrorAttrs(attr, collect)
+		}
+	}
+	return &errorHandler{Handler: h.Handler.WithAttrs(attrs), config: h.config, spans: h.spans, errs: errs}
+}
+
+func (h *errorHandler) WithGroup(name string) slog.Handler {
+	return &errorHandler{Handler: h.Handler.WithGroup(name), config: h.config, spans: h.spans, errs: h.errs}
+}
+
+// enrichErrorAttr replaces error values, also inside groups, with a group
+// describing the error. collect is called with every error attribute found.
+func enrichErrorAttr(attr slog.Attr, stack []string, collect func(slog.Attr)) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		members := make([]slog.Attr, len(group))
+		for i, member := range group {
+			members[i] = enrichErrorAttr(member, stack, collect)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(members...)}
+	case slog.KindAny:
+		err, ok := attr.Value.Any().(error)
+		if !ok || err == nil {
+			return attr
+		}
+		collect(attr)
+
+		fields := []slog.Attr{
+			slog.String("message", err.Error()),
+			slog.String("type", fmt.Sprintf("%T", err)),
+		}
+		if chain := errorChain(err); len(chain) > 0 {
+			fields = append(fields, slog.Any("chain", chain))
+		}
+		if len(stack) > 0 {
+			fields = append(fields, slog.Any("stack", stack))
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(fields...)}
+	}
+	return attr
+}
+
+func walkErrorAttrs(attr slog.Attr, collect func(slog.Attr)) {
+	attr.Value = attr.Value.Resolve()
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		for _, member := range attr.Value.Group() {
+			walkErrorAttrs(member, collect)
+		}
+	case slog.KindAny:
+		collect(attr)
+	}
+}
+
+// errorChain lists the errors wrapped by err, depth first, following both
+// errors.Unwrap and the multiple errors of errors.Join
+func errorChain(err error) []errorLink {
+	var chain []errorLink
+	var walk func(error)
+	walk = func(err error) {
+		var wrapped []error
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			if cause := e.Unwrap(); cause != nil {
+				wrapped = []error{cause}
+			}
+		case interface{ Unwrap() []error }:
+			wrapped = e.Unwrap()
+		}
+		for _, cause := range wrapped {
+			if cause == nil {
+				continue
+			}
+			chain = append(chain, errorLink{Message: cause.Error(), Type: fmt.Sprintf("%T", cause)})
+			walk(cause)
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// captureStack returns the current call stack starting at the frame that logged
+// the record, formatted as "function file:line"
+func captureStack(pc uintptr) []string {
+	pcs := make([]uintptr, maxStackDepth+16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var caller runtime.Frame
+	if pc != 0 {
+		caller, _ = runtime.CallersFrames([]uintptr{pc}).Next()
+	}
+
+	var stack []string
+	found := caller.Function == ""
+	for {
+		frame, more := frames.Next()
+		if !found && frame.Function == caller.Function && frame.Line == caller.Line {
+			found = true
+		}
+		if found && len(stack) < maxStackDepth {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// recordSpanErrors records errs on the span in ctx and marks it as failed with
+// message. A record without error values leaves the span alone, since logging
+// at ERROR does not mean that the operation failed.
+func recordSpanErrors(ctx context.Context, message string, errs []error) {
+	if len(errs) == 0 {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	for _, err := range errs {
+		span.RecordError(err)
+	}
+	span.SetStatus(codes.Error, message)
+}
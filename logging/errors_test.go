@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestErrorLogger(buf *bytes.Buffer, config ErrorConfig, spans bool) *slog.Logger {
+	return slog.New(&errorHandler{Handler: slog.NewJSONHandler(buf, nil), config: config, spans: spans})
+}
+
+func TestErrorEnrichment(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestErrorLogger(&buf, ErrorConfig{Enabled: true}, false)
+
+	err := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "app.yaml", Err: fs.ErrNotExist})
+	logger.Warn("falling back to defaults", "error", err)
+
+	group, ok := decodeRecord(t, &buf)["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected error group, got %s", buf.String())
+	}
+	if group["message"] != err.Error() {
+		t.Errorf("Expected message %q, got %v", err.Error(), group["message"])
+	}
+	if group["type"] != "*fmt.wrapError" {
+		t.Errorf("Expected type *fmt.wrapError, got %v", group["type"])
+	}
+	chain, ok := group["chain"].([]any)
+	if !ok || len(chain) != 2 {
+		t.Fatalf("Expected chain of 2 errors, got %v", group["chain"])
+	}
+	if link := chain[0].(map[string]any); link["type"] != "*fs.PathError" {
+		t.Errorf("Expected *fs.PathError first in chain, got %v", link)
+	}
+	if _, ok := group["stack"]; ok {
+		t.Error("Expected no stack below ERROR")
+	}
+}
+
+func TestErrorEnrichmentJoin(t *testing.T) {
+	chain := errorChain(errors.Join(errors.New("a"), fmt.Errorf("b: %w", errors.New("c"))))
+
+	var messages []string
+	for _, link := range chain {
+		messages = append(messages, link.Message)
+	}
+	if got := strings.Join(messages, ","); got != "a,b: c,c" {
+		t.Errorf("Expected chain a,b: c,c, got %s", got)
+	}
+}
+
+func TestErrorStack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestErrorLogger(&buf, ErrorConfig{Enabled: true, Stack: true}, false)
+
+	logger.Error("failed", slog.Group("op", "error", errors.New("boom")))
+
+	op := decodeRecord(t, &buf)["op"].(map[string]any)
+	group := op["error"].(map[string]any)
+	stack, ok := group["stack"].([]any)
+	if !ok || len(stack) == 0 {
+		t.Fatalf("Expected stack, got %v", group)
+	}
+	if first := stack[0].(string); !strings.Contains(first, "TestErrorStack") {
+		t.Errorf("Expected stack to start at the caller, got %s", first)
+	}
+}
+
+func TestErrorSpanRecording(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer tp.Shutdown(context.Background())
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	var buf bytes.Buffer
+	logger := newTestErrorLogger(&buf, ErrorConfig{}, true)
+
+	logger.WarnContext(ctx, "retrying", "error", errors.New("timeout"))
+	logger.ErrorContext(ctx, "request failed", "error", errors.New("connection reset"))
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(ended))
+	}
+	if status := ended[0].Status(); status.Code != codes.Error || status.Description != "request failed" {
+		t.Errorf("Expected error status, got %+v", status)
+	}
+	events := ended[0].Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("Expected 1 exception event, got %v", events)
+	}
+	for _, attr := range events[0].Attributes {
+		if attr.Key == "exception.message" && attr.Value.AsString() != "connection reset" {
+			t.Errorf("Expected exception message connection reset, got %s", attr.Value.AsString())
+		}
+	}
+}
+
+func TestErrorSpanRecordingWithoutError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer tp.Shutdown(context.Background())
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	var buf bytes.Buffer
+	logger := newTestErrorLogger(&buf, ErrorConfig{}, true)
+
+	logger.ErrorContext(ctx, "cache unavailable, using database", "status", 503)
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(ended))
+	}
+	if status := ended[0].Status(); status.Code != codes.Unset {
+		t.Errorf("Expected unset status without an error value, got %+v", status)
+	}
+	if events := ended[0].Events(); len(events) != 0 {
+		t.Errorf("Expected no exception events, got %v", events)
+	}
+	if buf.Len() == 0 {
+		t.Error("Expected record to be logged")
+	}
+}
+
+func TestErrorSpanRecordingWithAttrs(t *testing.T) {
+	for _, config := range []ErrorConfig{{}, {Enabled: true}} {
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+		var buf bytes.Buffer
+		logger := newTestErrorLogger(&buf, config, true).With("err", errors.New("connection reset")).WithGroup("req")
+
+		logger.ErrorContext(ctx, "request failed")
+		span.End()
+		tp.Shutdown(context.Background())
+
+		ended := recorder.Ended()
+		if len(ended) != 1 {
+			t.Fatalf("Expected 1 span, got %d", len(ended))
+		}
+		if status := ended[0].Status(); status.Code != codes.Error {
+			t.Errorf("Expected error status with %+v, got %+v", config, status)
+		}
+		events := ended[0].Events()
+		if len(events) != 1 {
+			t.Fatalf("Expected 1 exception event with %+v, got %v", config, events)
+		}
+		for _, attr := range events[0].Attributes {
+			if attr.Key == "exception.message" && attr.Value.AsString() != "connection reset" {
+				t.Errorf("Expected the error added with With to be recorded, got %s", attr.Value.AsString())
+			}
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Format selects the record layout written by a sink
+type Format string
+
+const (
+	// FormatText is slog's key=value text output
+	FormatText Format = "text"
+	// FormatJSON is slog's JSON output with time, level and msg keys
+	FormatJSON Format = "json"
+	// FormatLogfmt is logfmt with ts, lowercase level, msg and caller keys
+	FormatLogfmt Format = "logfmt"
+	// FormatECS is JSON using Elastic Common Schema field names
+	FormatECS Format = "ecs"
+	// FormatGCP is JSON using Google Cloud Logging structured logging fields
+	FormatGCP Format = "gcp"
+	// FormatOTel is JSON following the OpenTelemetry log data model
+	FormatOTel Format = "otel"
+	// FormatConsole is colored, aligned output for reading in a terminal
+	FormatConsole Format = "console"
+)
+
+// ecsVersion is the Elastic Common Schema version the ECS format follows
+const ecsVersion = "8.11.0"
+
+// resolveFormat returns format, falling back to json or text depending on asJSON
+func resolveFormat(format Format, asJSON bool) Format {
+	if format != "" {
+		return Format(strings.ToLower(string(format)))
+	}
+	if asJSON {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// newFormatHandler builds the handler writing sink records to output in the sink's format
+func newFormatHandler(sink SinkConfig, output io.Writer, opts slog.HandlerOptions) (slog.Handler, error) {
+	format := resolveFormat(sink.Format, sink.AsJSON)
+
+	var traceAttrs func(trace.SpanContext) []slog.Attr
+	var group string
+	var attrs []slog.Attr
+	var handler slog.Handler
+
+	switch format {
+	case FormatText, FormatJSON:
+		if format == FormatJSON {
+			handler = slog.NewJSONHandler(output, &opts)
+		} else {
+			handler = slog.NewTextHandler(output, &opts)
+		}
+		if sink.Tracing {
+			if err := sink.TraceFields.validate(); err != nil {
+				return nil, err
+			}
+		}
+		traceAttrs = sink.TraceFields.attrs
+	case FormatLogfmt:
+		opts.ReplaceAttr = replaceLogfmtAttr
+		handler = slog.NewTextHandler(output, &opts)
+		traceAttrs = func(sc trace.SpanContext) []slog.Attr {
+			return []slog.Attr{
+				slog.String("trace_id", sc.TraceID().String()),
+				slog.String("span_id", sc.SpanID().String()),
+			}
+		}
+	case FormatECS:
+		opts.ReplaceAttr = replaceECSAttr
+		handler = slog.NewJSONHandler(output, &opts)
+		attrs = []slog.Attr{slog.String("ecs.version", ecsVersion)}
+		traceAttrs = func(sc trace.SpanContext) []slog.Attr {
+			return []slog.Attr{
+				slog.String("trace.id", sc.TraceID().String()),
+				slog.String("span.id", sc.SpanID().String()),
+			}
+		}
+	case FormatGCP:
+		opts.ReplaceAttr = replaceGCPAttr
+		handler = slog.NewJSONHandler(output, &opts)
+		traceAttrs = func(sc trace.SpanContext) []slog.Attr {
+			traceID := sc.TraceID().String()
+			if sink.GCPProjectID != "" {
+				traceID = "projects/" + sink.GCPProjectID + "/traces/" + traceID
+			}
+			return []slog.Attr{
+				slog.String("logging.googleapis.com/trace", traceID),
+				slog.String("logging.googleapis.com/spanId", sc.SpanID().String()),
+				slog.Bool("logging.googleapis.com/trace_sampled", sc.IsSampled()),
+			}
+		}
+	case FormatOTel:
+		opts.ReplaceAttr = replaceOTelAttr
+		handler = slog.NewJSONHandler(output, &opts)
+		group = "attributes"
+		traceAttrs = func(sc trace.SpanContext) []slog.Attr {
+			return []slog.Attr{
+				slog.String("trace_id", sc.TraceID().String()),
+				slog.String("span_id", sc.SpanID().String()),
+				slog.String("trace_flags", sc.TraceFlags().String()),
+			}
+		}
+	case FormatConsole:
+		handler = NewConsoleHandler(output, &ConsoleHandlerOptions{
+			Level:     opts.Level,
+			AddSource: opts.AddSource,
+			NoColor:   os.Getenv("NO_COLOR") != "",
+		})
+		traceAttrs = func(sc trace.SpanContext) []slog.Attr {
+			return []slog.Attr{
+				slog.String("trace_id", sc.TraceID().String()),
+				slog.String("span_id", sc.SpanID().String()),
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported log format: %s", format)
+	}
+
+	if len(attrs) > 0 {
+		handler = handler.WithAttrs(attrs)
+	}
+	if !sink.Tracing {
+		traceAttrs = nil
+	}
+	return newLayoutHandler(handler, group, traceAttrs), nil
+}
+
+// layoutHandler keeps trace fields at the top level of a record, where backends
+// look for them, even when attributes are nested under a format or user group.
+// WithAttrs and WithGroup calls are replayed on top of the trace fields for
+// records logged inside a span, and the result is cached for the spans seen last.
+type layoutHandler struct {
+	base       slog.Handler
+	group      string
+	traceAttrs func(trace.SpanContext) []slog.Attr
+	with       []func(slog.Handler) slog.Handler
+	// handler is base with group and the with calls applied, used outside spans
+	handler slog.Handler
+	// spans holds the handlers built for the trace fields of the spans seen last
+	spans spanCache
+}
+
+// spanCacheSize bounds the handlers cached per layoutHandler, enough for the
+// spans a logger is typically used with concurrently
+const spanCacheSize = 16
+
+// spanCache is a small LRU cache of handlers by span. A linear scan is cheaper
+// than hashing for this few entries.
+type spanCache struct {
+	mu sync.Mutex
+	// entries are ordered from the most to the least recently used
+	entries []layoutSpan
+}
+
+func (c *spanCache) get(key layoutSpanKey) (slog.Handler, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, entry := range c.entries {
+		if entry.key == key {
+			copy(c.entries[1:i+1], c.entries[:i])
+			c.entries[0] = entry
+			return entry.handler, true
+		}
+	}
+	return nil, false
+}
+
+func (c *spanCache) add(key layoutSpanKey, handler slog.Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) < spanCacheSize {
+		c.entries = append(c.entries, layoutSpan{})
+	}
+	copy(c.entries[1:], c.entries)
+	c.entries[0] = layoutSpan{key: key, handler: handler}
+}
+
+// layoutSpan is a handler chain built on top of the trace fields of one span
+type layoutSpan struct {
+	key     layoutSpanKey
+	handler slog.Handler
+}
+
+// layoutSpanKey holds the parts of a span context that trace fields are built from
+type layoutSpanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+	flags   trace.TraceFlags
+}
+
+func newLayoutHandler(base slog.Handler, group string, traceAttrs func(trace.SpanContext) []slog.Attr) *layoutHandler {
+	h := &layoutHandler{base: base, group: group, traceAttrs: traceAttrs}
+	h.handler = h.build(base)
+	return h
+}
+
+func (h *layoutHandler) build(handler slog.Handler) slog.Handler {
+	if h.group != "" {
+		handler = handler.WithGroup(h.group)
+	}
+	for _, with := range h.with {
+		handler = with(handler)
+	}
+	return handler
+}
+
+func (h *layoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *layoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.traceAttrs != nil {
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			return h.spanHandler(spanContext).Handle(ctx, record)
+		}
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// spanHandler returns the handler chain with the trace fields of spanContext,
+// rebuilding it only when the span is not cached
+func (h *layoutHandler) spanHandler(spanContext trace.SpanContext) slog.Handler {
+	key := layoutSpanKey{
+		traceID: spanContext.TraceID(),
+		spanID:  spanContext.SpanID(),
+		flags:   spanContext.TraceFlags(),
+	}
+	if handler, ok := h.spans.get(key); ok {
+		return handler
+	}
+
+	// Built outside the lock; concurrent misses for one span build it twice at worst
+	handler := h.build(h.base.WithAttrs(h.traceAttrs(spanContext)))
+	h.spans.add(key, handler)
+	return handler
+}
+
+func (h *layoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.withOp(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *layoutHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withOp(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *layoutHandler) withOp(with func(slog.Handler) slog.Handler) *layoutHandler {
+	return &layoutHandler{
+		base:       h.base,
+		group:      h.group,
+		traceAttrs: h.traceAttrs,
+		with:       append(slices.Clip(h.with), with),
+		handler:    with(h.handler),
+	}
+}
+
+func replaceLogfmtAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.TimeKey:
+		attr.Key = "ts"
+	case slog.LevelKey:
+		attr.Value = slog.StringValue(strings.ToLower(attr.Value.String()))
+	case slog.SourceKey:
+		if source, ok := attr.Value.Any().(*slog.Source); ok {
+			return slog.String("caller", fmt.Sprintf("%s:%d", source.File, source.Line))
+		}
+	}
+	return attr
+}
+
+func replaceECSAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.TimeKey:
+		attr.Key = "@timestamp"
+	case slog.LevelKey:
+		return slog.String("log.level", strings.ToLower(attr.Value.String()))
+	case slog.MessageKey:
+		attr.Key = "message"
+	case loggerKey:
+		attr.Key = "log.logger"
+	case slog.SourceKey:
+		if source, ok := attr.Value.Any().(*slog.Source); ok {
+			return slog.Group("log.origin",
+				slog.Group("file", slog.String("name", source.File), slog.Int("line", source.Line)),
+				slog.String("function", source.Function),
+			)
+		}
+	}
+	return attr
+}
+
+func replaceGCPAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.LevelKey:
+		if level, ok := attr.Value.Any().(slog.Level); ok {
+			return slog.String("severity", gcpSeverity(level))
+		}
+	case slog.MessageKey:
+		attr.Key = "message"
+	case slog.SourceKey:
+		if source, ok := attr.Value.Any().(*slog.Source); ok {
+			return slog.Group("logging.googleapis.com/sourceLocation",
+				slog.String("file", source.File),
+				slog.Int("line", source.Line),
+				slog.String("function", source.Function),
+			)
+		}
+	}
+	return attr
+}
+
+// gcpSeverity maps slog levels onto Cloud Logging LogSeverity names
+func gcpSeverity(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return "DEBUG"
+	case level < slog.LevelWarn:
+		return "INFO"
+	case level < slog.LevelError:
+		return "WARNING"
+	case level < slog.LevelError+4:
+		return "ERROR"
+	default:
+		return "CRITICAL"
+	}
+}
+
+// replaceOTelAttr renames the built-in fields after the OpenTelemetry log data
+// model. Groups with an empty key are inlined by the JSON handler.
+func replaceOTelAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.TimeKey:
+		attr.Key = "timestamp"
+	case slog.LevelKey:
+		if level, ok := attr.Value.Any().(slog.Level); ok {
+			return slog.Group("",
+				slog.String("severity_text", level.String()),
+				slog.Int("severity_number", int(otelSeverity(level))),
+			)
+		}
+	case slog.MessageKey:
+		attr.Key = "body"
+	case slog.SourceKey:
+		if source, ok := attr.Value.Any().(*slog.Source); ok {
+			return slog.Group("",
+				slog.String("code.filepath", source.File),
+				slog.Int("code.lineno", source.Line),
+				slog.String("code.function", source.Function),
+			)
+		}
+	}
+	return attr
+}
@@ -0,0 +1,265 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(t testing.TB) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("Failed to parse trace ID: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("Failed to parse span ID: %v", err)
+	}
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), spanContext)
+}
+
+func newTestFormatLogger(t *testing.T, buf *bytes.Buffer, sink SinkConfig) *slog.Logger {
+	t.Helper()
+	sink.Tracing = true
+	handler, err := newFormatHandler(sink, buf, slog.HandlerOptions{AddSource: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return slog.New(handler)
+}
+
+func TestFormatECS(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatECS}).With(loggerKey, "db").WithGroup("query")
+
+	logger.WarnContext(testSpanContext(t), "slow query", "ms", 1500)
+
+	record := decodeRecord(t, &buf)
+	for key, want := range map[string]any{
+		"log.level":   "warn",
+		"message":     "slow query",
+		"log.logger":  "db",
+		"ecs.version": ecsVersion,
+		"trace.id":    "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span.id":     "00f067aa0ba902b7",
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s to be %v, got %v", key, want, record[key])
+		}
+	}
+	if _, ok := record["@timestamp"]; !ok {
+		t.Error("Expected @timestamp field")
+	}
+	if query, ok := record["query"].(map[string]any); !ok || query["ms"] != float64(1500) {
+		t.Errorf("Expected query.ms to be 1500, got %v", record["query"])
+	}
+	origin, ok := record["log.origin"].(map[string]any)
+	if !ok || origin["function"] == "" {
+		t.Errorf("Expected log.origin with function, got %v", record["log.origin"])
+	}
+}
+
+func TestFormatGCP(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatGCP, GCPProjectID: "my-project"})
+
+	logger.ErrorContext(testSpanContext(t), "failed")
+
+	record := decodeRecord(t, &buf)
+	for key, want := range map[string]any{
+		"severity":                             "ERROR",
+		"message":                              "failed",
+		"logging.googleapis.com/trace":         "projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736",
+		"logging.googleapis.com/spanId":        "00f067aa0ba902b7",
+		"logging.googleapis.com/trace_sampled": true,
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s to be %v, got %v", key, want, record[key])
+		}
+	}
+	if _, ok := record["logging.googleapis.com/sourceLocation"].(map[string]any); !ok {
+		t.Errorf("Expected sourceLocation, got %v", record["logging.googleapis.com/sourceLocation"])
+	}
+}
+
+func TestGCPSeverity(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  string
+	}{
+		{slog.LevelDebug, "DEBUG"},
+		{slog.LevelInfo, "INFO"},
+		{slog.LevelWarn, "WARNING"},
+		{slog.LevelError, "ERROR"},
+		{slog.LevelError + 4, "CRITICAL"},
+	}
+	for _, tt := range tests {
+		if got := gcpSeverity(tt.level); got != tt.want {
+			t.Errorf("Expected %s for %v, got %s", tt.want, tt.level, got)
+		}
+	}
+}
+
+func TestFormatOTel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatOTel}).With(loggerKey, "api")
+
+	logger.InfoContext(testSpanContext(t), "request served", "status", 200)
+
+	record := decodeRecord(t, &buf)
+	for key, want := range map[string]any{
+		"severity_text":   "INFO",
+		"severity_number": float64(9),
+		"body":            "request served",
+		"trace_id":        "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":         "00f067aa0ba902b7",
+		"trace_flags":     "01",
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s to be %v, got %v", key, want, record[key])
+		}
+	}
+	attributes, ok := record["attributes"].(map[string]any)
+	if !ok || attributes["status"] != float64(200) || attributes[loggerKey] != "api" {
+		t.Errorf("Expected status and logger under attributes, got %v", record["attributes"])
+	}
+	if _, ok := record["code.function"]; !ok {
+		t.Error("Expected code.function field")
+	}
+}
+
+func TestFormatLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatLogfmt})
+
+	logger.InfoContext(testSpanContext(t), "started", "port", 8080)
+
+	output := buf.String()
+	for _, want := range []string{"ts=", "level=info", "msg=started", "port=8080", "caller=", "trace_id=4bf92f3577b34da6a3ce929d0e0e4736"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got %s", want, output)
+		}
+	}
+}
+
+func TestFormatWithoutSpan(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatECS})
+
+	logger.Info("no span")
+
+	if record := decodeRecord(t, &buf); record["trace.id"] != nil {
+		t.Errorf("Expected no trace.id outside a span, got %v", record["trace.id"])
+	}
+}
+
+func TestResolveFormat(t *testing.T) {
+	if got := resolveFormat("", true); got != FormatJSON {
+		t.Errorf("Expected json, got %s", got)
+	}
+	if got := resolveFormat("", false); got != FormatText {
+		t.Errorf("Expected text, got %s", got)
+	}
+	if got := resolveFormat("ECS", false); got != FormatECS {
+		t.Errorf("Expected ecs, got %s", got)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	err := ConfigureLogging(LoggingConfig{Format: "xml", Writer: &bytes.Buffer{}})
+	if err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestFormatSpanHandlerCache(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestFormatLogger(t, &buf, SinkConfig{Format: FormatLogfmt}).With("user", "alice")
+
+	ctx := testSpanContext(t)
+	logger.InfoContext(ctx, "first")
+	logger.InfoContext(ctx, "second")
+
+	otherSpanID, _ := trace.SpanIDFromHex("1111111111111111")
+	other := trace.SpanContextFromContext(ctx).WithSpanID(otherSpanID)
+	logger.InfoContext(trace.ContextWithSpanContext(ctx, other), "third")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", buf.String())
+	}
+	for i, want := range []string{"span_id=00f067aa0ba902b7", "span_id=00f067aa0ba902b7", "span_id=1111111111111111"} {
+		if !strings.Contains(lines[i], want) || !strings.Contains(lines[i], "user=alice") {
+			t.Errorf("Expected line %d to contain %s and user=alice, got %q", i, want, lines[i])
+		}
+	}
+}
+
+func TestSpanCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	var cache spanCache
+	key := func(i int) layoutSpanKey {
+		return layoutSpanKey{spanID: trace.SpanID{byte(i + 1)}}
+	}
+	for i := 0; i < spanCacheSize; i++ {
+		cache.add(key(i), slog.NewTextHandler(io.Discard, nil))
+	}
+	// Using the oldest entry keeps it over the next oldest one
+	if _, ok := cache.get(key(0)); !ok {
+		t.Fatal("Expected first span to be cached")
+	}
+	cache.add(key(spanCacheSize), slog.NewTextHandler(io.Discard, nil))
+
+	if _, ok := cache.get(key(0)); !ok {
+		t.Error("Expected recently used span to stay cached")
+	}
+	if _, ok := cache.get(key(1)); ok {
+		t.Error("Expected least recently used span to be evicted")
+	}
+	if len(cache.entries) != spanCacheSize {
+		t.Errorf("Expected %d cached spans, got %d", spanCacheSize, len(cache.entries))
+	}
+}
+
+func BenchmarkFormatInSpan(b *testing.B) {
+	handler, err := newFormatHandler(SinkConfig{Format: FormatECS, Tracing: true}, io.Discard, slog.HandlerOptions{})
+	if err != nil {
+		b.Fatalf("Expected no error, got %v", err)
+	}
+	logger := slog.New(handler).With("service", "api").With("user", "alice").WithGroup("req").With("path", "/orders")
+	ctx := testSpanContext(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.InfoContext(ctx, "handled", "status", 200)
+	}
+}
+
+func BenchmarkFormatInterleavedSpans(b *testing.B) {
+	handler, err := newFormatHandler(SinkConfig{Format: FormatECS, Tracing: true}, io.Discard, slog.HandlerOptions{})
+	if err != nil {
+		b.Fatalf("Expected no error, got %v", err)
+	}
+	logger := slog.New(handler).With("service", "api").With("user", "alice").WithGroup("req").With("path", "/orders")
+	ctx := testSpanContext(b)
+	otherSpanID, _ := trace.SpanIDFromHex("1111111111111111")
+	other := trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(ctx).WithSpanID(otherSpanID))
+	contexts := []context.Context{ctx, other}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.InfoContext(contexts[i%2], "handled", "status", 200)
+	}
+}
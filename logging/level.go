@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// levelVar backs the level of every handler built by ConfigureLogging
+	levelVar slog.LevelVar
+
+	levelMu     sync.Mutex
+	revertTimer *time.Timer
+	revertAt    time.Time
+	revertLevel slog.Level
+)
+
+// LevelVar returns the level variable used by the handlers built by ConfigureLogging
+func LevelVar() *slog.LevelVar {
+	return &levelVar
+}
+
+// GetLevel returns the current global log level
+func GetLevel() slog.Level {
+	return levelVar.Level()
+}
+
+// SetLevel changes the global log level and cancels any pending revert
+func SetLevel(level slog.Level) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	cancelRevert()
+	levelVar.Set(level)
+}
+
+// SetLevelFor changes the global log level and reverts it after ttl. Calling it
+// again before the revert extends the change but still reverts to the level that
+// was set before the first temporary change.
+func SetLevelFor(level slog.Level, ttl time.Duration) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if revertTimer == nil {
+		revertLevel = levelVar.Level()
+	} else {
+		revertTimer.Stop()
+	}
+
+	levelVar.Set(level)
+	revertAt = time.Now().Add(ttl)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		levelMu.Lock()
+		defer levelMu.Unlock()
+
+		// A newer change has replaced this timer
+		if revertTimer != timer {
+			return
+		}
+		levelVar.Set(revertLevel)
+		revertTimer = nil
+		revertAt = time.Time{}
+	})
+	revertTimer = timer
+}
+
+// LevelRevertAt returns when a temporary level change will be reverted, or the
+// zero time if the current level is permanent
+func LevelRevertAt() time.Time {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	return revertAt
+}
+
+func cancelRevert() {
+	if revertTimer != nil {
+		revertTimer.Stop()
+		revertTimer = nil
+		revertAt = time.Time{}
+	}
+}
+
+// ParseLevel parses a level name such as "debug", "WARNING" or "INFO+2"
+func ParseLevel(level string) (slog.Level, error) {
+	if strings.EqualFold(level, "WARNING") {
+		return slog.LevelWarn, nil
+	}
+
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level: %s", level)
+	}
+	return parsed, nil
+}
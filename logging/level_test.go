@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel(slog.LevelInfo)
+
+	ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+	if GetLevel() != slog.LevelInfo {
+		t.Errorf("Expected level to be INFO after ConfigureLogging, got %v", GetLevel())
+	}
+
+	SetLevel(slog.LevelDebug)
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected default logger to be enabled for DEBUG after SetLevel")
+	}
+}
+
+func TestSetLevelFor(t *testing.T) {
+	defer SetLevel(slog.LevelInfo)
+
+	SetLevel(slog.LevelWarn)
+	SetLevelFor(slog.LevelDebug, 50*time.Millisecond)
+
+	if GetLevel() != slog.LevelDebug {
+		t.Errorf("Expected level to be DEBUG, got %v", GetLevel())
+	}
+	if LevelRevertAt().IsZero() {
+		t.Error("Expected a pending revert")
+	}
+
+	// A second temporary change still reverts to the original level
+	SetLevelFor(slog.LevelInfo, 50*time.Millisecond)
+
+	time.Sleep(150 * time.Millisecond)
+
+	if GetLevel() != slog.LevelWarn {
+		t.Errorf("Expected level to revert to WARN, got %v", GetLevel())
+	}
+	if !LevelRevertAt().IsZero() {
+		t.Error("Expected no pending revert after the TTL expired")
+	}
+}
+
+func TestSetLevelCancelsRevert(t *testing.T) {
+	defer SetLevel(slog.LevelInfo)
+
+	SetLevelFor(slog.LevelDebug, 50*time.Millisecond)
+	SetLevel(slog.LevelError)
+
+	time.Sleep(150 * time.Millisecond)
+
+	if GetLevel() != slog.LevelError {
+		t.Errorf("Expected SetLevel to cancel the revert, got %v", GetLevel())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  slog.Level
+		shouldErr bool
+	}{
+		{"debug", slog.LevelDebug, false},
+		{"WARNING", slog.LevelWarn, false},
+		{"warn", slog.LevelWarn, false},
+		{"INFO+2", slog.LevelInfo + 2, false},
+		{"invalid", 0, true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseLevel(test.input)
+		if test.shouldErr {
+			if err == nil {
+				t.Errorf("ParseLevel(%s) should return error", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLevel(%s) should not return error, got %v", test.input, err)
+		}
+		if result != test.expected {
+			t.Errorf("ParseLevel(%s) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
@@ -2,64 +2,210 @@ package logging
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
-	"os"
+	"slices"
+	"sync"
+	"time"
 
-	"go.opentelemetry.io/otel/trace"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// ConfigureLogging configures structured logging with the given configuration
-func ConfigureLogging(config LoggingConfig) {
-	var handler slog.Handler
+const (
+	// reconfigureTimeout bounds closing the sinks of a previous ConfigureLogging call
+	reconfigureTimeout = 5 * time.Second
+)
 
-	opts := &slog.HandlerOptions{
-		Level:     config.Level,
-		AddSource: true,
+var (
+	closersMu sync.Mutex
+	// closers flush and release the sinks created by the last ConfigureLogging call
+	closers []func(context.Context) error
+)
+
+// ConfigureLogging configures structured logging with the given configuration.
+// Sinks created by a previous call are flushed and closed. On error the previous
+// configuration stays in effect.
+func ConfigureLogging(config LoggingConfig) error {
+	if config.Async.Enabled {
+		switch config.Async.Policy {
+		case "", AsyncBlock, AsyncDropNewest, AsyncDropOldest:
+		default:
+			return fmt.Errorf("unsupported async policy: %s", config.Async.Policy)
+		}
+	}
+	if config.CountRecords && config.Metrics == nil {
+		return errors.New("counting log records requires a metrics collector")
+	}
+
+	var handlers []slog.Handler
+	var newClosers []func(context.Context) error
+	fail := func(err error) error {
+		return errors.Join(err, runClosers(context.Background(), newClosers))
+	}
+
+	// The primary sink is gated by the runtime level and per-logger overrides
+	primary, closer, err := newSinkHandler(config.primarySink(), minLevel)
+	if err != nil {
+		return err
+	}
+	if closer != nil {
+		newClosers = append(newClosers, closer)
 	}
+	handlers = append(handlers, &levelHandler{Handler: primary})
 
-	if config.AsJSON {
-		handler = slog.NewJSONHandler(os.Stdout, opts)
-	} else {
-		handler = slog.NewTextHandler(os.Stdout, opts)
+	for _, sink := range config.Sinks {
+		handler, closer, err := newSinkHandler(sink, sink.Level)
+		if err != nil {
+			return fail(err)
+		}
+		if closer != nil {
+			newClosers = append(newClosers, closer)
+		}
+		handlers = append(handlers, handler)
+	}
+
+	if config.OTLP.ExportType != "" {
+		provider, shutdown, err := newOTLPLoggerProvider(context.Background(), config.OTLP)
+		if err != nil {
+			return fail(err)
+		}
+		newClosers = append(newClosers, shutdown)
+		handlers = append(handlers, &levelHandler{Handler: newOTLPHandler(provider)})
 	}
 
-	if config.Tracing {
-		handler = &tracingHandler{Handler: handler}
+	var memory *MemoryBuffer
+	if config.Memory.Enabled {
+		memory = NewMemoryBuffer(config.Memory.Size)
+		handlers = append(handlers, &levelHandler{Handler: &memoryHandler{buffer: memory}})
+	}
+
+	var handler slog.Handler = handlers[0]
+	if len(handlers) > 1 {
+		handler = NewMultiHandler(handlers...)
+	}
+	if config.Redact.Enabled {
+		handler = &redactHandler{Handler: handler, redactor: newRedactor(config.Redact)}
+	}
+	handler = &contextHandler{Handler: handler, baggage: config.Baggage}
+	if config.Async.Enabled {
+		var depth prometheus.Gauge
+		var dropped *prometheus.CounterVec
+		if config.Metrics != nil {
+			if depth, err = config.Metrics.RegisterGauge(queueDepthOpts); err != nil {
+				return fail(err)
+			}
+			if dropped, err = config.Metrics.RegisterCounterVec(droppedRecordsOpts, []string{"level"}); err != nil {
+				return fail(err)
+			}
+		}
+		queue := newAsyncQueue(config.Async, depth, dropped)
+		newClosers = append(newClosers, queue.stop)
+		handler = &asyncHandler{Handler: handler, queue: queue}
+	}
+	if config.Errors.Enabled || config.Tracing {
+		handler = &errorHandler{Handler: handler, config: config.Errors, spans: config.Tracing}
+	}
+	if config.Sampling.Enabled {
+		var suppressed *prometheus.CounterVec
+		if config.Metrics != nil {
+			if suppressed, err = config.Metrics.RegisterCounterVec(suppressedRecordsOpts, []string{"level"}); err != nil {
+				return fail(err)
+			}
+		}
+		s := newSampler(config.Sampling, handler, suppressed)
+		newClosers = append(newClosers, s.stop)
+		handler = &samplingHandler{Handler: handler, sampler: s}
+	}
+	if config.CountRecords {
+		records, err := config.Metrics.RegisterCounterVec(recordsOpts, []string{"level", "logger"})
+		if err != nil {
+			return fail(err)
+		}
+		handler = &countingHandler{Handler: handler, records: records}
 	}
 
+	// Global state is only changed once nothing can fail anymore
+	SetLevel(config.Level)
+	setLoggerLevels(config.LoggerLevels)
+	memoryBuffer.Store(memory)
 	slog.SetDefault(slog.New(handler))
-}
 
-// GetLogger returns a logger with the given name
-func GetLogger(name string) *slog.Logger {
-	return slog.With("logger", name)
-}
+	closersMu.Lock()
+	previous := closers
+	closers = newClosers
+	closersMu.Unlock()
 
-// tracingHandler wraps slog.Handler to add OpenTelemetry tracing information
-type tracingHandler struct {
-	slog.Handler
+	ctx, cancel := context.WithTimeout(context.Background(), reconfigureTimeout)
+	defer cancel()
+	return runClosers(ctx, previous)
 }
 
-func (h *tracingHandler) Handle(ctx context.Context, record slog.Record) error {
-	span := trace.SpanFromContext(ctx)
-	spanContext := span.SpanContext()
+// newSinkHandler builds the formatting handler for a single sink
+func newSinkHandler(sink SinkConfig, level slog.Leveler) (slog.Handler, func(context.Context) error, error) {
+	output, closer, err := openOutput(sink.Output, sink.Writer, sink.File)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Add tracing information if we have a valid span context
-	if spanContext.IsValid() {
-		spanInfo := map[string]any{
-			"span_id":  spanContext.SpanID().String(),
-			"trace_id": spanContext.TraceID().String(),
+	handler, err := newFormatHandler(sink, output, slog.HandlerOptions{
+		Level:     level,
+		AddSource: true,
+	})
+	if err != nil {
+		if closer != nil {
+			err = errors.Join(err, closer(context.Background()))
 		}
-		record.Add("span", spanInfo)
+		return nil, nil, err
+	}
+	return handler, closer, nil
+}
+
+// Shutdown flushes and closes the sinks created by ConfigureLogging, such as the
+// OTLP exporter and log files. Records logged afterwards are dropped by closed sinks.
+func Shutdown(ctx context.Context) error {
+	closersMu.Lock()
+	previous := closers
+	closers = nil
+	closersMu.Unlock()
+
+	return runClosers(ctx, previous)
+}
+
+// runClosers runs closers in reverse order, so that handlers added on top of the
+// sinks flush into them before the sinks are closed
+func runClosers(ctx context.Context, closers []func(context.Context) error) error {
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		errs = append(errs, closers[i](ctx))
 	}
+	return errors.Join(errs...)
+}
 
-	return h.Handler.Handle(ctx, record)
+// GetLogger returns a logger with the given name
+func GetLogger(name string) *slog.Logger {
+	return slog.With(loggerKey, name)
 }
 
-func (h *tracingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &tracingHandler{Handler: h.Handler.WithAttrs(attrs)}
+// slogGroupOrAttrs records a WithGroup or WithAttrs call of a handler that
+// renders attributes itself
+type slogGroupOrAttrs struct {
+	group string
+	attrs []slog.Attr
 }
 
-func (h *tracingHandler) WithGroup(name string) slog.Handler {
-	return &tracingHandler{Handler: h.Handler.WithGroup(name)}
+// nestAttrs wraps record attributes in the open groups, placing attributes added
+// with WithAttrs at the level they were added at. Empty groups are dropped.
+func nestAttrs(goas []slogGroupOrAttrs, attrs []slog.Attr) []slog.Attr {
+	for i := len(goas) - 1; i >= 0; i-- {
+		goa := goas[i]
+		if goa.group == "" {
+			attrs = append(slices.Clone(goa.attrs), attrs...)
+			continue
+		}
+		if len(attrs) > 0 {
+			attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+		}
+	}
+	return attrs
 }
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// loggerKey is the attribute GetLogger uses to name a logger
+	loggerKey = "logger"
+
+	// minLevel lets every record through a handler so that levelHandler alone decides
+	minLevel = slog.Level(math.MinInt32)
+)
+
+var (
+	// loggerLevels holds an immutable snapshot of per-logger level overrides
+	loggerLevels   atomic.Pointer[map[string]slog.Level]
+	loggerLevelsMu sync.Mutex
+)
+
+// SetLoggerLevel overrides the level of the named logger and of every logger below
+// it in the dotted hierarchy, so "db" also applies to "db.pool"
+func SetLoggerLevel(name string, level slog.Level) {
+	updateLoggerLevels(func(levels map[string]slog.Level) {
+		levels[name] = level
+	})
+}
+
+// ResetLoggerLevel removes the override of the named logger
+func ResetLoggerLevel(name string) {
+	updateLoggerLevels(func(levels map[string]slog.Level) {
+		delete(levels, name)
+	})
+}
+
+// LoggerLevels returns a copy of the per-logger level overrides
+func LoggerLevels() map[string]slog.Level {
+	if levels := loggerLevels.Load(); levels != nil {
+		return maps.Clone(*levels)
+	}
+	return map[string]slog.Level{}
+}
+
+func setLoggerLevels(levels map[string]slog.Level) {
+	loggerLevelsMu.Lock()
+	defer loggerLevelsMu.Unlock()
+
+	snapshot := maps.Clone(levels)
+	if snapshot == nil {
+		snapshot = map[string]slog.Level{}
+	}
+	loggerLevels.Store(&snapshot)
+}
+
+func updateLoggerLevels(update func(map[string]slog.Level)) {
+	loggerLevelsMu.Lock()
+	defer loggerLevelsMu.Unlock()
+
+	snapshot := map[string]slog.Level{}
+	if levels := loggerLevels.Load(); levels != nil {
+		snapshot = maps.Clone(*levels)
+	}
+	update(snapshot)
+	loggerLevels.Store(&snapshot)
+}
+
+// loggerLevel finds the override for a logger, walking up the dotted hierarchy
+func loggerLevel(name string) (slog.Level, bool) {
+	levels := loggerLevels.Load()
+	if levels == nil || len(*levels) == 0 || name == "" {
+		return 0, false
+	}
+
+	for {
+		if level, ok := (*levels)[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// levelHandler decides whether a record is enabled using the global LevelVar and
+// the override of the logger name attached by GetLogger
+type levelHandler struct {
+	slog.Handler
+	logger  string
+	grouped bool
+}
+
+func (h *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	threshold, ok := loggerLevel(h.logger)
+	if !ok {
+		threshold = levelVar.Level()
+	}
+	return level >= threshold
+}
+
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	logger := withLoggerName(h.logger, h.grouped, attrs)
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), logger: logger, grouped: h.grouped}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), logger: h.logger, grouped: true}
+}
+
+// withLoggerName returns the logger name attached by GetLogger among attrs, or
+// logger if there is none. Attributes inside a group are not logger names.
+func withLoggerName(logger string, grouped bool, attrs []slog.Attr) string {
+	if grouped {
+		return logger
+	}
+	for _, attr := range attrs {
+		if attr.Key == loggerKey && attr.Value.Kind() == slog.KindString {
+			logger = attr.Value.String()
+		}
+	}
+	return logger
+}
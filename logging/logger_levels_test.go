@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTestLevelLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(&levelHandler{Handler: slog.NewTextHandler(buf, &slog.HandlerOptions{Level: minLevel})})
+}
+
+func TestLoggerLevelOverrides(t *testing.T) {
+	defer SetLevel(slog.LevelInfo)
+	defer setLoggerLevels(nil)
+
+	SetLevel(slog.LevelInfo)
+	setLoggerLevels(map[string]slog.Level{"db": slog.LevelDebug, "http": slog.LevelWarn})
+
+	var buf bytes.Buffer
+	root := newTestLevelLogger(&buf)
+
+	root.With(loggerKey, "db.pool").Debug("pool debug")
+	root.With(loggerKey, "http").Info("http info")
+	root.With(loggerKey, "other").Debug("other debug")
+	root.Debug("root debug")
+	root.Info("root info")
+
+	output := buf.String()
+	if !strings.Contains(output, "pool debug") {
+		t.Error("Expected db.pool to inherit the DEBUG override of db")
+	}
+	if strings.Contains(output, "http info") {
+		t.Error("Expected http INFO records to be dropped by the WARN override")
+	}
+	if strings.Contains(output, "other debug") || strings.Contains(output, "root debug") {
+		t.Error("Expected loggers without overrides to use the global level")
+	}
+	if !strings.Contains(output, "root info") {
+		t.Error("Expected root INFO records to be logged")
+	}
+}
+
+func TestLoggerLevelRuntimeChange(t *testing.T) {
+	defer setLoggerLevels(nil)
+
+	var buf bytes.Buffer
+	logger := newTestLevelLogger(&buf).With(loggerKey, "cache")
+
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected DEBUG to be disabled without an override")
+	}
+
+	SetLoggerLevel("cache", slog.LevelDebug)
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected DEBUG to be enabled after SetLoggerLevel")
+	}
+	if LoggerLevels()["cache"] != slog.LevelDebug {
+		t.Errorf("Expected LoggerLevels to report the override, got %v", LoggerLevels())
+	}
+
+	ResetLoggerLevel("cache")
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected DEBUG to be disabled after ResetLoggerLevel")
+	}
+}
+
+func TestLoggerLevelIgnoresGroupedAttr(t *testing.T) {
+	defer setLoggerLevels(nil)
+	setLoggerLevels(map[string]slog.Level{"db": slog.LevelDebug})
+
+	var buf bytes.Buffer
+	logger := newTestLevelLogger(&buf).WithGroup("request").With(loggerKey, "db")
+
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected a grouped logger attribute to not select an override")
+	}
+}
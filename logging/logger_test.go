@@ -1,8 +1,16 @@
 package logging
 
 import (
+	"bytes"
+	"context"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging/logtest"
 )
 
 func TestConfigureLogging(t *testing.T) {
@@ -45,4 +53,118 @@ func TestGetLogger(t *testing.T) {
 	if logger2 == nil {
 		t.Error("Expected GetLogger to return non-nil logger for second call")
 	}
+
+	logs := logtest.Capture(t)
+	GetLogger("test-logger").Info("named")
+	logs.AssertLogged(t, logtest.Message("named"), logtest.Logger("test-logger"))
+}
+
+func TestConfigureLoggingWithFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	config := LoggingConfig{
+		Level:  slog.LevelInfo,
+		AsJSON: true,
+		Output: path,
+	}
+	if err := ConfigureLogging(config); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	GetLogger("test").Info("written to file")
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("Failed to shut down logging: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"written to file"`) {
+		t.Errorf("Expected log file to contain the record, got %q", data)
+	}
+}
+
+func TestShutdownFlushesSamplingSummaryToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	config := LoggingConfig{
+		Level:  slog.LevelInfo,
+		AsJSON: true,
+		Output: path,
+		Sampling: SamplingConfig{
+			Enabled:        true,
+			Interval:       time.Hour,
+			SamplingLimits: SamplingLimits{First: 1},
+		},
+	}
+	if err := ConfigureLogging(config); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		GetLogger("test").Info("storm")
+	}
+	// The sampler must flush its summary before the file sink is closed
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("Failed to shut down logging: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"suppressed 4 similar records"`) {
+		t.Errorf("Expected log file to contain the sampling summary, got %q", data)
+	}
+}
+
+func TestConfigureLoggingWithWriter(t *testing.T) {
+	var buf bytes.Buffer
+	config := LoggingConfig{
+		Level:  slog.LevelInfo,
+		Output: "/nonexistent/ignored.log",
+		Writer: &buf,
+	}
+	if err := ConfigureLogging(config); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	slog.Info("written to writer")
+	if !strings.Contains(buf.String(), "written to writer") {
+		t.Errorf("Expected writer to receive the record, got %q", buf.String())
+	}
+}
+
+func TestConfigureLoggingErrorKeepsPreviousConfig(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ConfigureLogging(LoggingConfig{Level: slog.LevelWarn, Writer: &buf}); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	err := ConfigureLogging(LoggingConfig{
+		Level:        slog.LevelDebug,
+		Writer:       &bytes.Buffer{},
+		LoggerLevels: map[string]slog.Level{"db": slog.LevelDebug},
+		Memory:       MemoryConfig{Enabled: true},
+		Async:        AsyncConfig{Enabled: true, Policy: "spill"},
+	})
+	if err == nil {
+		t.Fatal("Expected error for unsupported async policy")
+	}
+
+	if level := GetLevel(); level != slog.LevelWarn {
+		t.Errorf("Expected level to stay WARN, got %v", level)
+	}
+	if levels := LoggerLevels(); len(levels) != 0 {
+		t.Errorf("Expected no logger levels, got %v", levels)
+	}
+	if MemoryLogs() != nil {
+		t.Error("Expected no memory buffer")
+	}
+	slog.Warn("still configured")
+	if !strings.Contains(buf.String(), "still configured") {
+		t.Errorf("Expected the previous handler to stay in place, got %q", buf.String())
+	}
 }
@@ -0,0 +1,320 @@
+// Package logtest captures log records in tests so that they can be asserted on.
+//
+//	func TestCheckout(t *testing.T) {
+//		logs := logtest.Capture(t)
+//
+//		checkout(ctx)
+//
+//		logs.AssertLogged(t, logtest.Level(slog.LevelWarn), logtest.Message("payment retried"), logtest.Attr("attempt", 2))
+//	}
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// loggerKey is the attribute logging.GetLogger uses to name a logger
+const loggerKey = "logger"
+
+// Record is a captured log record
+type Record struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	// Attrs holds the record's attributes, including those added with With, nested
+	// under the groups they were logged in
+	Attrs   []slog.Attr
+	TraceID string
+	SpanID  string
+}
+
+// Value looks up an attribute by key, using dots to reach into groups, e.g. "req.path"
+func (r Record) Value(key string) (slog.Value, bool) {
+	attrs := r.Attrs
+	path := strings.Split(key, ".")
+	for i, name := range path {
+		var found bool
+		for _, attr := range attrs {
+			if attr.Key != name {
+				continue
+			}
+			value := attr.Value.Resolve()
+			if i == len(path)-1 {
+				return value, true
+			}
+			if value.Kind() == slog.KindGroup {
+				attrs = value.Group()
+				found = true
+				break
+			}
+		}
+		if !found {
+			return slog.Value{}, false
+		}
+	}
+	return slog.Value{}, false
+}
+
+// Has reports whether the record has an attribute with the given key
+func (r Record) Has(key string) bool {
+	_, ok := r.Value(key)
+	return ok
+}
+
+// String returns the string attribute with the given key, or "" when missing
+func (r Record) String(key string) string {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindString {
+		return value.String()
+	}
+	return ""
+}
+
+// Int returns the integer attribute with the given key, or 0 when missing
+func (r Record) Int(key string) int64 {
+	value, ok := r.Value(key)
+	switch {
+	case !ok:
+		return 0
+	case value.Kind() == slog.KindInt64:
+		return value.Int64()
+	case value.Kind() == slog.KindUint64 && value.Uint64() <= math.MaxInt64:
+		return int64(value.Uint64())
+	}
+	return 0
+}
+
+// Float returns the float attribute with the given key, or 0 when missing
+func (r Record) Float(key string) float64 {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindFloat64 {
+		return value.Float64()
+	}
+	return 0
+}
+
+// Bool returns the boolean attribute with the given key, or false when missing
+func (r Record) Bool(key string) bool {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindBool {
+		return value.Bool()
+	}
+	return false
+}
+
+// Duration returns the duration attribute with the given key, or 0 when missing
+func (r Record) Duration(key string) time.Duration {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindDuration {
+		return value.Duration()
+	}
+	return 0
+}
+
+// TimeAttr returns the time attribute with the given key, or the zero time when missing
+func (r Record) TimeAttr(key string) time.Time {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindTime {
+		return value.Time()
+	}
+	return time.Time{}
+}
+
+// Error returns the error attribute with the given key, or nil when missing
+func (r Record) Error(key string) error {
+	if value, ok := r.Value(key); ok && value.Kind() == slog.KindAny {
+		err, _ := value.Any().(error)
+		return err
+	}
+	return nil
+}
+
+// Any returns the value of the attribute with the given key, or nil when missing
+func (r Record) Any(key string) any {
+	if value, ok := r.Value(key); ok {
+		return value.Any()
+	}
+	return nil
+}
+
+// Logger returns the name attached by logging.GetLogger
+func (r Record) Logger() string {
+	return r.String(loggerKey)
+}
+
+func (r Record) describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", r.Level, r.Message)
+	for _, attr := range r.Attrs {
+		fmt.Fprintf(&b, " %s", attr)
+	}
+	if r.TraceID != "" {
+		fmt.Fprintf(&b, " trace_id=%s", r.TraceID)
+	}
+	return b.String()
+}
+
+// Recorder collects the records logged while it is installed
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// Capture installs a Recorder as the default logger for the duration of the test.
+// Records of every level are captured. The previous default logger and the
+// output of the log package are restored on t.Cleanup. Since the default logger
+// is global, Capture must not be used in parallel tests; use Handler with a
+// logger passed to the code under test there instead.
+func Capture(t testing.TB) *Recorder {
+	t.Helper()
+
+	previous := slog.Default()
+	writer, flags := log.Writer(), log.Flags()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+	})
+
+	r := &Recorder{}
+	slog.SetDefault(slog.New(r.Handler()))
+	return r
+}
+
+// Handler returns a handler adding records to r, for loggers that do not use the default
+func (r *Recorder) Handler() slog.Handler {
+	return &handler{recorder: r}
+}
+
+// Records returns the captured records in the order they were logged
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.records)
+}
+
+// Reset drops the captured records
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// Find returns the records matching every matcher
+func (r *Recorder) Find(matchers ...Matcher) []Record {
+	var found []Record
+	for _, record := range r.Records() {
+		if matchAll(record, matchers) {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+// Logged reports whether any record matches every matcher
+func (r *Recorder) Logged(matchers ...Matcher) bool {
+	return len(r.Find(matchers...)) > 0
+}
+
+// AssertLogged fails the test unless a record matches every matcher and returns
+// the first such record
+func (r *Recorder) AssertLogged(t testing.TB, matchers ...Matcher) Record {
+	t.Helper()
+	found := r.Find(matchers...)
+	if len(found) == 0 {
+		t.Errorf("Expected a record matching %s, got:%s", describeMatchers(matchers), r.describe())
+		return Record{}
+	}
+	return found[0]
+}
+
+// AssertNotLogged fails the test if any record matches every matcher
+func (r *Recorder) AssertNotLogged(t testing.TB, matchers ...Matcher) {
+	t.Helper()
+	if found := r.Find(matchers...); len(found) > 0 {
+		t.Errorf("Expected no record matching %s, got %s", describeMatchers(matchers), found[0].describe())
+	}
+}
+
+func (r *Recorder) add(record Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+}
+
+func (r *Recorder) describe() string {
+	records := r.Records()
+	if len(records) == 0 {
+		return " no records"
+	}
+	var b strings.Builder
+	for _, record := range records {
+		b.WriteString("\n\t")
+		b.WriteString(record.describe())
+	}
+	return b.String()
+}
+
+// groupOrAttrs records a WithGroup or WithAttrs call
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+type handler struct {
+	recorder *Recorder
+	goas     []groupOrAttrs
+}
+
+func (h *handler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			attrs = append(slices.Clone(goa.attrs), attrs...)
+		} else if len(attrs) > 0 {
+			attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+		}
+	}
+
+	captured := Record{
+		Time:    record.Time,
+		Level:   record.Level,
+		Message: record.Message,
+		Attrs:   attrs,
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		captured.TraceID = spanContext.TraceID().String()
+		captured.SpanID = spanContext.SpanID().String()
+	}
+	h.recorder.add(captured)
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return &handler{recorder: h.recorder, goas: append(slices.Clip(h.goas), groupOrAttrs{attrs: attrs})}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{recorder: h.recorder, goas: append(slices.Clip(h.goas), groupOrAttrs{group: name})}
+}
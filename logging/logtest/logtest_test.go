@@ -0,0 +1,135 @@
+package logtest
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCapture(t *testing.T) {
+	logs := Capture(t)
+
+	slog.Debug("debug is captured too")
+	slog.With(loggerKey, "payments").WithGroup("req").Warn("payment retried",
+		"attempt", 2,
+		"id", "p-1",
+		"took", 150*time.Millisecond,
+		"ok", false,
+		"ratio", 0.5,
+		"err", errors.New("declined"),
+	)
+
+	records := logs.Records()
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	record := logs.AssertLogged(t, Level(slog.LevelWarn), Logger("payments"), Attr("req.attempt", 2))
+	if got := record.Int("req.attempt"); got != 2 {
+		t.Errorf("Expected attempt 2, got %d", got)
+	}
+	if got := record.String("req.id"); got != "p-1" {
+		t.Errorf("Expected id p-1, got %s", got)
+	}
+	if got := record.Duration("req.took"); got != 150*time.Millisecond {
+		t.Errorf("Expected took 150ms, got %s", got)
+	}
+	if got := record.Float("req.ratio"); got != 0.5 {
+		t.Errorf("Expected ratio 0.5, got %v", got)
+	}
+	if record.Bool("req.ok") || !record.Has("req.ok") {
+		t.Error("Expected ok to be present and false")
+	}
+	if err := record.Error("req.err"); err == nil || err.Error() != "declined" {
+		t.Errorf("Expected declined error, got %v", err)
+	}
+	if record.Has("attempt") || record.String("req.missing") != "" {
+		t.Error("Expected lookups outside the group to fail")
+	}
+
+	logs.AssertNotLogged(t, MinLevel(slog.LevelError))
+	logs.Reset()
+	if len(logs.Records()) != 0 {
+		t.Error("Expected no records after Reset")
+	}
+}
+
+func TestCaptureRestoresDefault(t *testing.T) {
+	previous := slog.Default()
+	writer := log.Writer()
+
+	t.Run("capture", func(t *testing.T) {
+		logs := Capture(t)
+		log.Print("from the log package")
+		logs.AssertLogged(t, Message("from the log package"))
+	})
+
+	if slog.Default() != previous {
+		t.Error("Expected the previous default logger to be restored")
+	}
+	if log.Writer() != writer {
+		t.Error("Expected the log package output to be restored")
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	logs := &Recorder{}
+	logger := slog.New(logs.Handler())
+	logger.InfoContext(ctx, "order placed", "total", 42.5, "items", []string{"book", "pen"})
+	logger.Error("order failed")
+
+	tests := []struct {
+		name     string
+		matchers []Matcher
+		want     int
+	}{
+		{"none", nil, 2},
+		{"level", []Matcher{Level(slog.LevelInfo)}, 1},
+		{"min level", []Matcher{MinLevel(slog.LevelInfo)}, 2},
+		{"message", []Matcher{Message("order placed")}, 1},
+		{"message contains", []Matcher{MessageContains("order")}, 2},
+		{"attr", []Matcher{Attr("total", 42.5)}, 1},
+		{"attr mismatch", []Matcher{Attr("total", 1)}, 0},
+		{"slice attr", []Matcher{Attr("items", []string{"book", "pen"})}, 1},
+		{"slice attr mismatch", []Matcher{Attr("items", []string{"book"})}, 0},
+		{"map attr mismatch", []Matcher{Attr("items", map[string]int{"book": 1})}, 0},
+		{"has attr", []Matcher{HasAttr("total")}, 1},
+		{"trace", []Matcher{TraceID(traceID.String())}, 1},
+		{"all", []Matcher{Level(slog.LevelError), MessageContains("placed")}, 0},
+		{"custom", []Matcher{Match("no attrs", func(r Record) bool { return len(r.Attrs) == 0 })}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(logs.Find(tt.matchers...)); got != tt.want {
+				t.Errorf("Expected %d records, got %d", tt.want, got)
+			}
+		})
+	}
+
+	if record := logs.Find(TraceID(traceID.String()))[0]; record.SpanID != spanID.String() {
+		t.Errorf("Expected span ID %s, got %s", spanID, record.SpanID)
+	}
+}
+
+func TestAssertLoggedFailure(t *testing.T) {
+	logs := &Recorder{}
+	slog.New(logs.Handler()).Info("something else")
+
+	fake := &testing.T{}
+	logs.AssertLogged(fake, Message("expected"))
+	if !fake.Failed() {
+		t.Error("Expected AssertLogged to fail")
+	}
+}
@@ -0,0 +1,112 @@
+package logtest
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Matcher selects records in Find and the assertions
+type Matcher struct {
+	description string
+	match       func(Record) bool
+}
+
+// Match reports whether record matches
+func (m Matcher) Match(record Record) bool {
+	return m.match(record)
+}
+
+func (m Matcher) String() string {
+	return m.description
+}
+
+// Match builds a Matcher from a custom predicate
+func Match(description string, match func(Record) bool) Matcher {
+	return Matcher{description: description, match: match}
+}
+
+// Level matches records of exactly the given level
+func Level(level slog.Level) Matcher {
+	return Match("level="+level.String(), func(r Record) bool { return r.Level == level })
+}
+
+// MinLevel matches records at or above the given level
+func MinLevel(level slog.Level) Matcher {
+	return Match("level>="+level.String(), func(r Record) bool { return r.Level >= level })
+}
+
+// Message matches records with exactly the given message
+func Message(message string) Matcher {
+	return Match(fmt.Sprintf("message=%q", message), func(r Record) bool { return r.Message == message })
+}
+
+// MessageContains matches records whose message contains substr
+func MessageContains(substr string) Matcher {
+	return Match(fmt.Sprintf("message~%q", substr), func(r Record) bool { return strings.Contains(r.Message, substr) })
+}
+
+// Attr matches records with an attribute equal to value. The key may use dots to
+// reach into groups, and values are compared as slog values, so an int matches
+// an int64 attribute. Other values such as slices and maps are compared with
+// reflect.DeepEqual.
+func Attr(key string, value any) Matcher {
+	want := slog.AnyValue(value).Resolve()
+	return Match(fmt.Sprintf("%s=%v", key, value), func(r Record) bool {
+		got, ok := r.Value(key)
+		return ok && valuesEqual(got, want)
+	})
+}
+
+// valuesEqual is slog.Value.Equal without its panic on uncomparable values
+func valuesEqual(a, b slog.Value) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case slog.KindAny:
+		return reflect.DeepEqual(a.Any(), b.Any())
+	case slog.KindGroup:
+		return slices.EqualFunc(a.Group(), b.Group(), func(x, y slog.Attr) bool {
+			return x.Key == y.Key && valuesEqual(x.Value, y.Value)
+		})
+	}
+	return a.Equal(b)
+}
+
+// HasAttr matches records with an attribute with the given key
+func HasAttr(key string) Matcher {
+	return Match("has "+key, func(r Record) bool { return r.Has(key) })
+}
+
+// Logger matches records of the logger created by logging.GetLogger(name)
+func Logger(name string) Matcher {
+	return Match("logger="+name, func(r Record) bool { return r.Logger() == name })
+}
+
+// TraceID matches records logged with a context holding the given trace ID
+func TraceID(traceID string) Matcher {
+	return Match("trace_id="+traceID, func(r Record) bool { return r.TraceID == traceID })
+}
+
+func matchAll(record Record, matchers []Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Match(record) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeMatchers(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return "anything"
+	}
+	descriptions := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		descriptions[i] = matcher.String()
+	}
+	return strings.Join(descriptions, ", ")
+}
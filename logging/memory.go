@@ -0,0 +1,266 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultMemorySize is used when MemoryConfig.Size is not positive
+	defaultMemorySize = 500
+	// subscriberBuffer is the number of records a slow subscriber can fall behind
+	// before records are dropped for it
+	subscriberBuffer = 64
+)
+
+// memoryBuffer is the buffer of the last ConfigureLogging call, if enabled
+var memoryBuffer atomic.Pointer[MemoryBuffer]
+
+// MemoryConfig keeps the most recent records in memory, see MemoryLogs
+type MemoryConfig struct {
+	Enabled bool
+	// Size is the number of records kept; zero means 500
+	Size int
+}
+
+// MemoryRecord is a log record kept by a MemoryBuffer
+type MemoryRecord struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Logger  string         `json:"logger,omitempty"`
+	TraceID string         `json:"trace_id,omitempty"`
+	SpanID  string         `json:"span_id,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+
+	level slog.Level
+}
+
+// MemoryFilter selects records from a MemoryBuffer. Zero fields match everything.
+type MemoryFilter struct {
+	// Level is the minimum level
+	Level slog.Leveler
+	// Logger matches the named logger and the loggers below it, like SetLoggerLevel
+	Logger  string
+	TraceID string
+}
+
+// Match reports whether record passes the filter
+func (f MemoryFilter) Match(record MemoryRecord) bool {
+	if f.Level != nil && record.level < f.Level.Level() {
+		return false
+	}
+	if f.Logger != "" && record.Logger != f.Logger && !strings.HasPrefix(record.Logger, f.Logger+".") {
+		return false
+	}
+	return f.TraceID == "" || record.TraceID == f.TraceID
+}
+
+// MemoryBuffer is a ring buffer of the most recent log records
+type MemoryBuffer struct {
+	mu          sync.Mutex
+	records     []MemoryRecord
+	next        int
+	full        bool
+	subscribers map[chan MemoryRecord]struct{}
+}
+
+// NewMemoryBuffer creates a buffer keeping the last size records
+func NewMemoryBuffer(size int) *MemoryBuffer {
+	if size <= 0 {
+		size = defaultMemorySize
+	}
+	return &MemoryBuffer{
+		records:     make([]MemoryRecord, size),
+		subscribers: make(map[chan MemoryRecord]struct{}),
+	}
+}
+
+// MemoryLogs returns the buffer configured with LoggingConfig.Memory, or nil
+func MemoryLogs() *MemoryBuffer {
+	return memoryBuffer.Load()
+}
+
+// Records returns up to limit of the most recent records matching filter, oldest
+// first. A limit of zero returns all of them.
+func (b *MemoryBuffer) Records(filter MemoryFilter, limit int) []MemoryRecord {
+	b.mu.Lock()
+	ordered := slices.Clone(b.records[:b.next])
+	if b.full {
+		ordered = append(slices.Clone(b.records[b.next:]), ordered...)
+	}
+	b.mu.Unlock()
+
+	matched := make([]MemoryRecord, 0, len(ordered))
+	for _, record := range ordered {
+		if filter.Match(record) {
+			matched = append(matched, record)
+		}
+	}
+	if limit > 0 && len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	return matched
+}
+
+// Subscribe returns a channel receiving every record added from now on and a
+// function that ends the subscription. Records are dropped for subscribers that
+// do not keep up.
+func (b *MemoryBuffer) Subscribe() (<-chan MemoryRecord, func()) {
+	ch := make(chan MemoryRecord, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (b *MemoryBuffer) add(record MemoryRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.records[b.next] = record
+	b.next = (b.next + 1) % len(b.records)
+	if b.next == 0 {
+		b.full = true
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- record:
+		default:
+		}
+	}
+}
+
+// memoryHandler adds records to a MemoryBuffer
+type memoryHandler struct {
+	buffer *MemoryBuffer
+	logger string
+	goas   []slogGroupOrAttrs
+}
+
+func (h *memoryHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *memoryHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := MemoryRecord{
+		Time:    record.Time,
+		Level:   record.Level.String(),
+		Message: record.Message,
+		Logger:  h.logger,
+		level:   record.Level,
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		entry.TraceID = spanContext.TraceID().String()
+		entry.SpanID = spanContext.SpanID().String()
+	}
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	if attrs = nestAttrs(h.goas, attrs); len(attrs) > 0 {
+		entry.Attrs = attrMap(attrs)
+		// The logger name has its own field
+		if h.logger != "" {
+			delete(entry.Attrs, loggerKey)
+		}
+		if len(entry.Attrs) == 0 {
+			entry.Attrs = nil
+		}
+	}
+
+	h.buffer.add(entry)
+	return nil
+}
+
+func (h *memoryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	if len(h.goas) == 0 {
+		for _, attr := range attrs {
+			if attr.Key == loggerKey && attr.Value.Kind() == slog.KindString {
+				h2.logger = attr.Value.String()
+			}
+		}
+	}
+	h2.goas = append(slices.Clip(h.goas), slogGroupOrAttrs{attrs: attrs})
+	return &h2
+}
+
+func (h *memoryHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.goas = append(slices.Clip(h.goas), slogGroupOrAttrs{group: name})
+	return &h2
+}
+
+// attrMap converts attributes into JSON-friendly values, nesting groups as maps
+func attrMap(attrs []slog.Attr) map[string]any {
+	m := make(map[string]any, len(attrs))
+	for _, attr := range flattenAttrs(attrs) {
+		switch attr.Value.Kind() {
+		case slog.KindGroup:
+			m[attr.Key] = attrMap(attr.Value.Group())
+		case slog.KindDuration:
+			m[attr.Key] = attr.Value.Duration().String()
+		case slog.KindFloat64:
+			// NaN and infinities have no JSON encoding
+			if f := attr.Value.Float64(); math.IsNaN(f) || math.IsInf(f, 0) {
+				m[attr.Key] = attr.Value.String()
+			} else {
+				m[attr.Key] = f
+			}
+		case slog.KindAny:
+			if err, ok := attr.Value.Any().(error); ok {
+				m[attr.Key] = err.Error()
+			} else {
+				m[attr.Key] = jsonCopy(attr.Value.Any())
+			}
+		default:
+			m[attr.Key] = attr.Value.Any()
+		}
+	}
+	return m
+}
+
+// jsonCopy returns v as decoded from its JSON encoding, or its fmt form when it
+// has none. Copying when the record is logged keeps later changes to v out of
+// the buffer and lets every kept record be encoded when it is served.
+func jsonCopy(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var copied any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return fmt.Sprint(v)
+	}
+	return copied
+}
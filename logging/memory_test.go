@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMemoryBufferKeepsMostRecent(t *testing.T) {
+	buffer := NewMemoryBuffer(3)
+	logger := slog.New(&memoryHandler{buffer: buffer})
+
+	for i := 0; i < 5; i++ {
+		logger.Info("record", "i", i)
+	}
+
+	records := buffer.Records(MemoryFilter{}, 0)
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	for i, record := range records {
+		if got := record.Attrs["i"]; got != int64(i+2) {
+			t.Errorf("Expected record %d to have i=%d, got %v", i, i+2, got)
+		}
+	}
+
+	if records := buffer.Records(MemoryFilter{}, 1); len(records) != 1 || records[0].Attrs["i"] != int64(4) {
+		t.Errorf("Expected only the newest record, got %v", records)
+	}
+}
+
+type memoryNode struct {
+	Name string
+	Next *memoryNode
+}
+
+func TestMemoryBufferCopiesValues(t *testing.T) {
+	buffer := NewMemoryBuffer(10)
+	logger := slog.New(&memoryHandler{buffer: buffer})
+
+	node := &memoryNode{Name: "before"}
+	cyclic := &memoryNode{Name: "cycle"}
+	cyclic.Next = cyclic
+	logger.Info("values", "node", node, "cyclic", cyclic, "fn", func() {}, "ch", make(chan int), "ratio", math.NaN())
+	node.Name = "after"
+
+	records := buffer.Records(MemoryFilter{}, 0)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if got := records[0].Attrs["node"].(map[string]any)["Name"]; got != "before" {
+		t.Errorf("Expected value as logged, got %v", got)
+	}
+	for _, key := range []string{"cyclic", "fn", "ch", "ratio"} {
+		if _, ok := records[0].Attrs[key].(string); !ok {
+			t.Errorf("Expected %s without a JSON encoding to be kept as text, got %T", key, records[0].Attrs[key])
+		}
+	}
+	if _, err := json.Marshal(records); err != nil {
+		t.Errorf("Expected records to encode, got %v", err)
+	}
+}
+
+func TestMemoryBufferFilter(t *testing.T) {
+	buffer := NewMemoryBuffer(10)
+	handler := &memoryHandler{buffer: buffer}
+
+	slog.New(handler).With(loggerKey, "db.pool").Debug("acquired")
+	slog.New(handler).With(loggerKey, "db").WarnContext(testSpanContext(t), "slow", "ms", 900)
+	slog.New(handler).With(loggerKey, "http").Error("failed", slog.Group("req", "path", "/"))
+
+	tests := []struct {
+		name   string
+		filter MemoryFilter
+		want   int
+	}{
+		{"all", MemoryFilter{}, 3},
+		{"level", MemoryFilter{Level: slog.LevelWarn}, 2},
+		{"logger hierarchy", MemoryFilter{Logger: "db"}, 2},
+		{"logger exact", MemoryFilter{Logger: "db.pool"}, 1},
+		{"trace", MemoryFilter{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buffer.Records(tt.filter, 0); len(got) != tt.want {
+				t.Errorf("Expected %d records, got %d", tt.want, len(got))
+			}
+		})
+	}
+
+	records := buffer.Records(MemoryFilter{Logger: "http"}, 0)
+	if req, ok := records[0].Attrs["req"].(map[string]any); !ok || req["path"] != "/" {
+		t.Errorf("Expected req group, got %v", records[0].Attrs)
+	}
+	if _, ok := records[0].Attrs[loggerKey]; ok {
+		t.Error("Expected logger name only in the Logger field")
+	}
+}
+
+func TestMemoryBufferSubscribe(t *testing.T) {
+	buffer := NewMemoryBuffer(10)
+	records, unsubscribe := buffer.Subscribe()
+
+	slog.New(&memoryHandler{buffer: buffer}).Info("live")
+
+	select {
+	case record := <-records:
+		if record.Message != "live" {
+			t.Errorf("Expected live record, got %v", record)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a record on the subscription")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-records; ok {
+		t.Error("Expected the subscription channel to be closed")
+	}
+}
+
+func TestConfigureLoggingWithMemory(t *testing.T) {
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	err := ConfigureLogging(LoggingConfig{
+		Level:  slog.LevelInfo,
+		Output: OutputStderr,
+		Memory: MemoryConfig{Enabled: true, Size: 10},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	GetLogger("app").Debug("below the level")
+	GetLogger("app").InfoContext(context.Background(), "kept")
+
+	records := MemoryLogs().Records(MemoryFilter{}, 0)
+	if len(records) != 1 || records[0].Message != "kept" || records[0].Logger != "app" {
+		t.Errorf("Expected only the INFO record, got %v", records)
+	}
+
+	if err := ConfigureLogging(LoggingConfig{Level: slog.LevelInfo}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if MemoryLogs() != nil {
+		t.Error("Expected no buffer once disabled")
+	}
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var recordsOpts = prometheus.CounterOpts{
+	Name: "log_records_total",
+	Help: "Number of log records by level and logger",
+}
+
+// countingHandler counts handled records by level and the logger name attached by GetLogger
+type countingHandler struct {
+	slog.Handler
+	records *prometheus.CounterVec
+	logger  string
+	grouped bool
+}
+
+func (h *countingHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.records.WithLabelValues(record.Level.String(), h.logger).Inc()
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	logger := withLoggerName(h.logger, h.grouped, attrs)
+	return &countingHandler{Handler: h.Handler.WithAttrs(attrs), records: h.records, logger: logger, grouped: h.grouped}
+}
+
+func (h *countingHandler) WithGroup(name string) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithGroup(name), records: h.records, logger: h.logger, grouped: true}
+}
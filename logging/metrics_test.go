@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+func TestCountRecords(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	err := ConfigureLogging(LoggingConfig{
+		Level:        slog.LevelInfo,
+		Writer:       &buf,
+		CountRecords: true,
+		Metrics:      collector,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	db := GetLogger("db")
+	db.Error("query failed")
+	db.Error("query failed")
+	db.WithGroup("pool").Warn("exhausted")
+	db.Debug("below the level")
+	slog.Info("unnamed")
+
+	records := collector.CounterVec(recordsOpts, []string{"level", "logger"})
+	tests := []struct {
+		level  string
+		logger string
+		want   float64
+	}{
+		{"ERROR", "db", 2},
+		{"WARN", "db", 1},
+		{"DEBUG", "db", 0},
+		{"INFO", "", 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(records.WithLabelValues(tt.level, tt.logger)); got != tt.want {
+			t.Errorf("Expected %v records for %s/%q, got %v", tt.want, tt.level, tt.logger, got)
+		}
+	}
+}
+
+func TestCountRecordsWithoutMetrics(t *testing.T) {
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	if err := ConfigureLogging(LoggingConfig{Level: slog.LevelInfo, Writer: &buf}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err := ConfigureLogging(LoggingConfig{Level: slog.LevelInfo, Writer: io.Discard, CountRecords: true})
+	if err == nil {
+		t.Fatal("Expected error for CountRecords without Metrics")
+	}
+
+	slog.Info("still logged")
+	if buf.Len() == 0 {
+		t.Error("Expected previous configuration to stay in effect")
+	}
+}
+
+func TestCountRecordsConflictingMetric(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+	collector.CounterVec(prometheus.CounterOpts{Name: recordsOpts.Name, Help: "Records"}, []string{"severity"})
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	err := ConfigureLogging(LoggingConfig{
+		Level:        slog.LevelInfo,
+		Writer:       &bytes.Buffer{},
+		CountRecords: true,
+		Metrics:      collector,
+	})
+	if err == nil {
+		t.Error("Expected an error for a conflicting log_records_total")
+	}
+}
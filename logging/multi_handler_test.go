@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingHandler struct {
+	slog.Handler
+}
+
+func (h failingHandler) Handle(context.Context, slog.Record) error {
+	return errors.New("sink unavailable")
+}
+
+func TestMultiHandler(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	handler := NewMultiHandler(
+		failingHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, nil)},
+		slog.NewJSONHandler(&jsonBuf, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		slog.NewTextHandler(&textBuf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	logger := slog.New(handler).With("component", "test")
+
+	if !handler.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected DEBUG to be enabled when any child enables it")
+	}
+
+	logger.Debug("debug only")
+	logger.Info("everywhere")
+
+	if strings.Contains(jsonBuf.String(), "debug only") {
+		t.Error("Expected INFO child to skip DEBUG records")
+	}
+	if !strings.Contains(jsonBuf.String(), `"msg":"everywhere","component":"test"`) {
+		t.Errorf("Expected JSON child to receive the INFO record, got %q", jsonBuf.String())
+	}
+	if !strings.Contains(textBuf.String(), "debug only") || !strings.Contains(textBuf.String(), "everywhere") {
+		t.Errorf("Expected DEBUG child to receive both records despite a failing sibling, got %q", textBuf.String())
+	}
+
+	err := handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "direct", 0))
+	if err == nil || !strings.Contains(err.Error(), "sink unavailable") {
+		t.Errorf("Expected the failing child's error to be reported, got %v", err)
+	}
+}
+
+func TestConfigureLoggingWithSinks(t *testing.T) {
+	defer SetLevel(slog.LevelInfo)
+
+	var primary, debugSink bytes.Buffer
+	config := LoggingConfig{
+		Level:  slog.LevelInfo,
+		AsJSON: true,
+		Writer: &primary,
+		Sinks: []SinkConfig{
+			{Level: slog.LevelDebug, Writer: &debugSink},
+		},
+	}
+	if err := ConfigureLogging(config); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	logger := GetLogger("sinks")
+	logger.Debug("debug detail")
+	logger.Info("info summary")
+
+	if strings.Contains(primary.String(), "debug detail") {
+		t.Error("Expected primary INFO output to skip DEBUG records")
+	}
+	if !strings.Contains(primary.String(), `"msg":"info summary"`) {
+		t.Errorf("Expected primary output to be JSON, got %q", primary.String())
+	}
+	if !strings.Contains(debugSink.String(), "msg=\"debug detail\"") || !strings.Contains(debugSink.String(), "logger=sinks") {
+		t.Errorf("Expected text sink to receive DEBUG records, got %q", debugSink.String())
+	}
+}
@@ -0,0 +1,252 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// instrumentationName is the OpenTelemetry scope name of exported log records
+	instrumentationName = "github.com/corruptmane/corrupt-o11y-go/logging"
+)
+
+// OTLPExportType selects the transport used to export log records
+type OTLPExportType string
+
+const (
+	OTLPExportHTTP OTLPExportType = "http"
+	OTLPExportGRPC OTLPExportType = "grpc"
+)
+
+// OTLPConfig holds configuration for exporting log records over OTLP
+type OTLPConfig struct {
+	// ExportType selects the http or grpc exporter; empty disables OTLP export
+	ExportType OTLPExportType
+	Endpoint   string
+	// ServiceName and ServiceVersion describe the service in the exported
+	// resource; o11y.Setup fills them in from the service metadata
+	ServiceName    string
+	ServiceVersion string
+}
+
+// newOTLPLoggerProvider creates a batching logger provider that exports to the configured collector
+func newOTLPLoggerProvider(ctx context.Context, config OTLPConfig) (*sdklog.LoggerProvider, func(context.Context) error, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(config.ServiceName),
+			semconv.ServiceVersionKey.String(config.ServiceVersion),
+		),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	var exporter sdklog.Exporter
+	var conn *grpc.ClientConn
+
+	switch config.ExportType {
+	case OTLPExportHTTP:
+		if config.Endpoint == "" {
+			return nil, nil, errors.New("HTTP log exporter requires an endpoint")
+		}
+		exporter, err = otlploghttp.New(ctx,
+			otlploghttp.WithEndpoint(config.Endpoint),
+			otlploghttp.WithInsecure(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create HTTP log exporter: %w", err)
+		}
+	case OTLPExportGRPC:
+		if config.Endpoint == "" {
+			return nil, nil, errors.New("GRPC log exporter requires an endpoint")
+		}
+		conn, err = grpc.NewClient(config.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+		}
+		exporter, err = otlploggrpc.New(ctx,
+			otlploggrpc.WithGRPCConn(conn),
+		)
+		if err != nil {
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("failed to create GRPC log exporter: %w", err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported log export type: %s", config.ExportType)
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	)
+
+	shutdown := func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if conn != nil {
+			err = errors.Join(err, conn.Close())
+		}
+		return err
+	}
+
+	return provider, shutdown, nil
+}
+
+// groupOrAttrs records a WithGroup or WithAttrs call so that attributes can be
+// nested under the groups that were open when they were added
+type groupOrAttrs struct {
+	group string
+	attrs []otellog.KeyValue
+}
+
+// otlpHandler converts slog records into OpenTelemetry log records. Trace and
+// span IDs are taken from the context by the logger provider.
+type otlpHandler struct {
+	logger otellog.Logger
+	goas   []groupOrAttrs
+}
+
+func newOTLPHandler(provider otellog.LoggerProvider) *otlpHandler {
+	return &otlpHandler{logger: provider.Logger(instrumentationName)}
+}
+
+func (h *otlpHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: otelSeverity(level)})
+}
+
+func (h *otlpHandler) Handle(ctx context.Context, record slog.Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(record.Time)
+	rec.SetObservedTimestamp(time.Now())
+	rec.SetSeverity(otelSeverity(record.Level))
+	rec.SetSeverityText(record.Level.String())
+	rec.SetBody(otellog.StringValue(record.Message))
+
+	attrs := make([]otellog.KeyValue, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendOTelAttr(attrs, attr)
+		return true
+	})
+	rec.AddAttributes(h.nest(attrs)...)
+
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+func (h *otlpHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	converted := make([]otellog.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		converted = appendOTelAttr(converted, attr)
+	}
+	return h.with(groupOrAttrs{attrs: converted})
+}
+
+func (h *otlpHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *otlpHandler) with(goa groupOrAttrs) *otlpHandler {
+	return &otlpHandler{logger: h.logger, goas: append(slices.Clip(h.goas), goa)}
+}
+
+// nest wraps record attributes in the open groups, placing attributes added with
+// WithAttrs at the level they were added at. Empty groups are dropped.
+func (h *otlpHandler) nest(attrs []otellog.KeyValue) []otellog.KeyValue {
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			attrs = append(slices.Clone(goa.attrs), attrs...)
+			continue
+		}
+		if len(attrs) > 0 {
+			attrs = []otellog.KeyValue{otellog.Map(goa.group, attrs...)}
+		}
+	}
+	return attrs
+}
+
+// otelSeverity maps slog levels onto the OpenTelemetry severity range, where
+// DEBUG, INFO, WARN and ERROR line up with their OpenTelemetry counterparts
+func otelSeverity(level slog.Level) otellog.Severity {
+	return otellog.Severity(min(max(int(level)+9, 1), 24))
+}
+
+// appendOTelAttr converts attr following slog rules: empty attributes are
+// dropped and groups without a key are inlined
+func appendOTelAttr(kvs []otellog.KeyValue, attr slog.Attr) []otellog.KeyValue {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return kvs
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		if len(group) == 0 {
+			return kvs
+		}
+		if attr.Key == "" {
+			for _, member := range group {
+				kvs = appendOTelAttr(kvs, member)
+			}
+			return kvs
+		}
+	}
+
+	return append(kvs, otellog.KeyValue{Key: attr.Key, Value: otelValue(attr.Value)})
+}
+
+func otelValue(value slog.Value) otellog.Value {
+	switch value.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(value.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(value.Int64())
+	case slog.KindUint64:
+		if u := value.Uint64(); u <= math.MaxInt64 {
+			return otellog.Int64Value(int64(u))
+		}
+		return otellog.StringValue(value.String())
+	case slog.KindFloat64:
+		return otellog.Float64Value(value.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(value.Bool())
+	case slog.KindDuration:
+		return otellog.StringValue(value.Duration().String())
+	case slog.KindTime:
+		return otellog.StringValue(value.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		kvs := make([]otellog.KeyValue, 0, len(value.Group()))
+		for _, member := range value.Group() {
+			kvs = appendOTelAttr(kvs, member)
+		}
+		return otellog.MapValue(kvs...)
+	default:
+		switch v := value.Any().(type) {
+		case error:
+			return otellog.StringValue(v.Error())
+		case []byte:
+			return otellog.BytesValue(v)
+		default:
+			return otellog.StringValue(fmt.Sprintf("%+v", v))
+		}
+	}
+}
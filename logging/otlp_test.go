@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func recordAttrs(record sdklog.Record) map[string]otellog.Value {
+	attrs := map[string]otellog.Value{}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestOTLPHandler(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	logger := slog.New(newOTLPHandler(provider)).
+		With(loggerKey, "db").
+		WithGroup("request").
+		With("method", "GET")
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	logger.WarnContext(ctx, "slow query", "duration_ms", 1200, "error", errors.New("timeout"))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("Expected 1 exported record, got %d", len(exporter.records))
+	}
+	record := exporter.records[0]
+
+	if record.Body().AsString() != "slow query" {
+		t.Errorf("Expected body to be 'slow query', got %s", record.Body().AsString())
+	}
+	if record.Severity() != otellog.SeverityWarn {
+		t.Errorf("Expected severity WARN, got %v", record.Severity())
+	}
+	if record.TraceID() != spanContext.TraceID() || record.SpanID() != spanContext.SpanID() {
+		t.Errorf("Expected trace context from ctx, got trace %s span %s", record.TraceID(), record.SpanID())
+	}
+
+	attrs := recordAttrs(record)
+	if attrs[loggerKey].AsString() != "db" {
+		t.Errorf("Expected logger attribute to be 'db', got %v", attrs[loggerKey])
+	}
+
+	request := map[string]otellog.Value{}
+	for _, kv := range attrs["request"].AsMap() {
+		request[kv.Key] = kv.Value
+	}
+	if request["method"].AsString() != "GET" {
+		t.Errorf("Expected request.method to be 'GET', got %v", request["method"])
+	}
+	if request["duration_ms"].AsInt64() != 1200 {
+		t.Errorf("Expected request.duration_ms to be 1200, got %v", request["duration_ms"])
+	}
+	if request["error"].AsString() != "timeout" {
+		t.Errorf("Expected request.error to be 'timeout', got %v", request["error"])
+	}
+}
+
+func TestOTelSeverity(t *testing.T) {
+	tests := []struct {
+		input    slog.Level
+		expected otellog.Severity
+	}{
+		{slog.LevelDebug, otellog.SeverityDebug},
+		{slog.LevelInfo, otellog.SeverityInfo},
+		{slog.LevelWarn, otellog.SeverityWarn},
+		{slog.LevelError, otellog.SeverityError},
+		{slog.Level(-100), otellog.SeverityTrace1},
+		{slog.Level(100), otellog.SeverityFatal4},
+	}
+
+	for _, test := range tests {
+		if result := otelSeverity(test.input); result != test.expected {
+			t.Errorf("otelSeverity(%v) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestConfigureLoggingWithInvalidOTLP(t *testing.T) {
+	config := LoggingConfig{
+		Level: slog.LevelInfo,
+		OTLP:  OTLPConfig{ExportType: OTLPExportHTTP},
+	}
+	if err := ConfigureLogging(config); err == nil {
+		t.Error("Expected error when HTTP log exporter has no endpoint")
+	}
+
+	config.OTLP.ExportType = "stdout"
+	if err := ConfigureLogging(config); err == nil {
+		t.Error("Expected error for unsupported log export type")
+	}
+}
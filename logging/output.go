@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+)
+
+// Standard stream names accepted as LoggingConfig.Output
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+// openOutput resolves where a handler writes to. A non-nil writer wins over
+// output, which is "stdout", "stderr" or a file path. The returned closer is nil
+// for destinations the logging package does not own.
+func openOutput(output string, writer io.Writer, file FileConfig) (io.Writer, func(context.Context) error, error) {
+	if writer != nil {
+		return writer, nil, nil
+	}
+
+	switch strings.ToLower(output) {
+	case "", OutputStdout:
+		return os.Stdout, nil, nil
+	case OutputStderr:
+		return os.Stderr, nil, nil
+	}
+
+	rotating, err := NewRotatingFile(output, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rotating, func(context.Context) error { return rotating.Close() }, nil
+}
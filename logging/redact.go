@@ -0,0 +1,296 @@
+package logging
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// redactedValue replaces masked values
+	redactedValue = "[REDACTED]"
+
+	// maxRedactDepth bounds how deep structs and maps are expanded for key matching
+	maxRedactDepth = 5
+)
+
+// DefaultRedactKeys are the attribute names masked when RedactConfig.Keys is empty
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "access_token", "refresh_token",
+	"api_key", "apikey", "authorization", "cookie", "set-cookie", "email",
+}
+
+// cardNumberPattern matches credit-card-like numbers; matches are only masked
+// when they pass the Luhn check
+var cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// DefaultRedactPatterns mask JWTs, bearer tokens and credit-card-like numbers
+// when RedactConfig.Patterns is empty
+var DefaultRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	cardNumberPattern,
+}
+
+// Redactor is implemented by types that control their own redacted form.
+// The returned value is logged in place of the original.
+type Redactor interface {
+	Redact() any
+}
+
+// RedactConfig controls masking of sensitive data in log records
+type RedactConfig struct {
+	Enabled bool
+	// Keys are attribute names to mask, matched case-insensitively inside groups,
+	// structs and maps too; empty uses DefaultRedactKeys
+	Keys []string
+	// Patterns mask matching parts of string values and messages; empty uses DefaultRedactPatterns
+	Patterns []*regexp.Regexp
+}
+
+// redactor applies a RedactConfig to attribute values
+type redactor struct {
+	keys     map[string]bool
+	patterns []*regexp.Regexp
+}
+
+func newRedactor(config RedactConfig) *redactor {
+	keys := config.Keys
+	if len(keys) == 0 {
+		keys = DefaultRedactKeys
+	}
+	patterns := config.Patterns
+	if len(patterns) == 0 {
+		patterns = DefaultRedactPatterns
+	}
+
+	r := &redactor{keys: make(map[string]bool, len(keys)), patterns: patterns}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = true
+	}
+	return r
+}
+
+func (r *redactor) attr(attr slog.Attr, depth int) slog.Attr {
+	if r.keys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redactedValue)
+	}
+	return slog.Attr{Key: attr.Key, Value: r.value(attr.Value, depth)}
+}
+
+func (r *redactor) value(value slog.Value, depth int) slog.Value {
+	if redactor, ok := anyValue(value).(Redactor); ok {
+		value = slog.AnyValue(redactor.Redact())
+	}
+	value = value.Resolve()
+	if redactor, ok := anyValue(value).(Redactor); ok {
+		value = slog.AnyValue(redactor.Redact()).Resolve()
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.StringValue(r.text(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, member := range group {
+			attrs[i] = r.attr(member, depth)
+		}
+		return slog.GroupValue(attrs...)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			// Keep an error so that handlers still render it as one, but without
+			// its causes since their messages are not redacted
+			if text := r.text(v.Error()); text != v.Error() {
+				return slog.AnyValue(redactedError{text})
+			}
+			return value
+		case fmt.Stringer:
+			if text := r.text(v.String()); text != v.String() {
+				return slog.StringValue(text)
+			}
+			return value
+		}
+		if depth < maxRedactDepth {
+			if elems, ok := r.slice(value.Any(), depth+1); ok {
+				return slog.AnyValue(elems)
+			}
+			if group, ok := expandValue(value.Any()); ok {
+				return r.value(group, depth+1)
+			}
+		}
+	}
+	return value
+}
+
+// slice redacts the elements of slices and arrays other than byte slices
+func (r *redactor) slice(v any, depth int) ([]any, bool) {
+	rv := reflect.ValueOf(v)
+	if kind := rv.Kind(); kind != reflect.Slice && kind != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	elems := make([]any, rv.Len())
+	for i := range elems {
+		elems[i] = valueAny(r.value(slog.AnyValue(rv.Index(i).Interface()), depth))
+	}
+	return elems, true
+}
+
+// valueAny returns the Go value of value, turning groups into maps
+func valueAny(value slog.Value) any {
+	if value.Kind() != slog.KindGroup {
+		return value.Any()
+	}
+	m := make(map[string]any, len(value.Group()))
+	for _, attr := range value.Group() {
+		m[attr.Key] = valueAny(attr.Value)
+	}
+	return m
+}
+
+// redactedError replaces an error whose message contained sensitive data
+type redactedError struct {
+	message string
+}
+
+func (e redactedError) Error() string {
+	return e.message
+}
+
+// text masks every pattern match. Card number matches failing the Luhn check are
+// kept so that ordinary long numbers are not mistaken for card numbers.
+func (r *redactor) text(s string) string {
+	for _, pattern := range r.patterns {
+		if pattern != cardNumberPattern {
+			s = pattern.ReplaceAllString(s, redactedValue)
+			continue
+		}
+		s = pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if digits, ok := onlyDigits(match); ok && !luhnValid(digits) {
+				return match
+			}
+			return redactedValue
+		})
+	}
+	return s
+}
+
+func anyValue(value slog.Value) any {
+	if value.Kind() != slog.KindAny {
+		return nil
+	}
+	return value.Any()
+}
+
+// expandValue turns structs and string-keyed maps into groups so that their
+// fields can be matched by key. Types with their own text or JSON form are kept.
+func expandValue(v any) (slog.Value, bool) {
+	switch v.(type) {
+	case nil, error, fmt.Stringer, json.Marshaler, encoding.TextMarshaler:
+		return slog.Value{}, false
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return slog.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		rt := rv.Type()
+		attrs := make([]slog.Attr, 0, rt.NumField())
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			attrs = append(attrs, slog.Any(name, rv.Field(i).Interface()))
+		}
+		return slog.GroupValue(attrs...), true
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return slog.Value{}, false
+		}
+		attrs := make([]slog.Attr, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			attrs = append(attrs, slog.Any(iter.Key().String(), iter.Value().Interface()))
+		}
+		return slog.GroupValue(attrs...), true
+	}
+	return slog.Value{}, false
+}
+
+func onlyDigits(s string) (string, bool) {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == ' ' || c == '-':
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// redactHandler masks sensitive attributes and message fragments before they
+// reach the wrapped handler
+type redactHandler struct {
+	slog.Handler
+	redactor *redactor
+}
+
+func (h *redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.text(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.attr(attr, 0))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redactor.attr(attr, 0)
+	}
+	return &redactHandler{Handler: h.Handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
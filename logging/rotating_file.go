@@ -0,0 +1,342 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// renameFile is replaced in tests to simulate failing renames
+var renameFile = os.Rename
+
+const (
+	// backupTimeFormat is appended to rotated file names and sorts chronologically
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+)
+
+// FileConfig controls rotation of a log file
+type FileConfig struct {
+	// MaxSize rotates the file once it would grow beyond this many bytes; zero disables
+	MaxSize int64
+	// RotateInterval rotates the file once it has been open this long; zero disables
+	RotateInterval time.Duration
+	// MaxBackups is the number of rotated files to keep; zero keeps all of them
+	MaxBackups int
+	// Compress gzips rotated files
+	Compress bool
+	// ReopenOnSIGHUP reopens the file on SIGHUP, for use with external tools like logrotate
+	ReopenOnSIGHUP bool
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and age
+type RotatingFile struct {
+	path   string
+	config FileConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+
+	// millMu serializes compression and pruning of rotated files
+	millMu sync.Mutex
+	millWg sync.WaitGroup
+
+	signals   chan os.Signal
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRotatingFile opens path for appending, creating it and its directory if needed
+func NewRotatingFile(path string, config FileConfig) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, config: config}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	if config.ReopenOnSIGHUP {
+		f.signals = make(chan os.Signal, 1)
+		f.done = make(chan struct{})
+		signal.Notify(f.signals, syscall.SIGHUP)
+		go f.watchSignals()
+	}
+
+	return f, nil
+}
+
+// Write writes p to the file, rotating it first if the size or age limit is reached
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	// A failed rotation is reported but does not stop logging
+	var rotateErr error
+	if f.shouldRotate(int64(len(p))) {
+		rotateErr = f.rotate()
+	}
+	// Retry opening the file if a previous rotation or reopen could not
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, errors.Join(rotateErr, err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// Rotate closes the current file, renames it with a timestamp suffix and opens a new one
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Reopen closes and reopens the file at the same path without renaming it
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	// Open the new file first so that a failure keeps the old one in use
+	previous := f.file
+	if err := f.open(); err != nil {
+		return err
+	}
+	if previous != nil {
+		if err := previous.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the file and waits for pending compression of rotated files.
+// Closing an already closed file does nothing.
+func (f *RotatingFile) Close() error {
+	f.closeOnce.Do(func() {
+		if f.done != nil {
+			signal.Stop(f.signals)
+			close(f.done)
+		}
+	})
+
+	f.mu.Lock()
+	f.closed = true
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+
+	f.millWg.Wait()
+	return err
+}
+
+func (f *RotatingFile) shouldRotate(n int64) bool {
+	if f.config.MaxSize > 0 && f.size > 0 && f.size+n > f.config.MaxSize {
+		return true
+	}
+	// An idle file is not rotated so that no empty backups are created
+	return f.config.RotateInterval > 0 && f.size > 0 && time.Since(f.openedAt) >= f.config.RotateInterval
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	return nil
+}
+
+// rotate renames the file and opens a new one. The file is closed before it is
+// renamed for platforms that cannot rename open files. If renaming fails the
+// file is reopened at its path; if opening fails Write retries it.
+func (f *RotatingFile) rotate() error {
+	var closeErr error
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			closeErr = fmt.Errorf("failed to close log file: %w", err)
+		}
+		f.file = nil
+	}
+
+	backup := f.backupName(time.Now())
+	if err := renameFile(f.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Join(closeErr, fmt.Errorf("failed to rename log file: %w", err), f.open())
+	}
+
+	f.millWg.Add(1)
+	go func() {
+		defer f.millWg.Done()
+		f.mill(backup)
+	}()
+
+	return errors.Join(closeErr, f.open())
+}
+
+// mill compresses a freshly rotated file and removes backups beyond MaxBackups.
+// Errors are ignored since there is nowhere left to report them.
+func (f *RotatingFile) mill(backup string) {
+	f.millMu.Lock()
+	defer f.millMu.Unlock()
+
+	if f.config.Compress {
+		_ = compressFile(backup)
+	}
+
+	if f.config.MaxBackups <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return
+	}
+	// Only count files rotated by this writer, leaving e.g. logrotate's app.log.1 alone
+	type backupFile struct {
+		name string
+		time time.Time
+		seq  int
+	}
+	var backups []backupFile
+	for _, match := range matches {
+		if rotatedAt, seq, ok := f.parseBackup(match); ok {
+			backups = append(backups, backupFile{name: match, time: rotatedAt, seq: seq})
+		}
+	}
+	// The oldest backups come first
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].time.Equal(backups[j].time) {
+			return backups[i].time.Before(backups[j].time)
+		}
+		return backups[i].seq < backups[j].seq
+	})
+	for len(backups) > f.config.MaxBackups {
+		_ = os.Remove(backups[0].name)
+		backups = backups[1:]
+	}
+}
+
+// backupName returns the name of a file rotated at now. Rotations within the same
+// millisecond get a sequence number so that they do not replace each other.
+func (f *RotatingFile) backupName(now time.Time) string {
+	base := f.path + "." + now.Format(backupTimeFormat)
+	name := base
+	for seq := 1; fileExists(name) || fileExists(name+compressSuffix); seq++ {
+		name = base + "." + strconv.Itoa(seq)
+	}
+	return name
+}
+
+// parseBackup reports whether name is a file rotated by this writer, that is the
+// path followed by a timestamp, an optional sequence number and optionally the
+// compression suffix, and returns its timestamp and sequence number
+func (f *RotatingFile) parseBackup(name string) (time.Time, int, bool) {
+	suffix, ok := strings.CutPrefix(name, f.path+".")
+	if !ok {
+		return time.Time{}, 0, false
+	}
+	suffix = strings.TrimSuffix(suffix, compressSuffix)
+
+	if rotatedAt, err := time.Parse(backupTimeFormat, suffix); err == nil {
+		return rotatedAt, 0, true
+	}
+	i := strings.LastIndexByte(suffix, '.')
+	if i < 0 {
+		return time.Time{}, 0, false
+	}
+	seq, err := strconv.Atoi(suffix[i+1:])
+	if err != nil || seq < 1 {
+		return time.Time{}, 0, false
+	}
+	rotatedAt, err := time.Parse(backupTimeFormat, suffix[:i])
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return rotatedAt, seq, true
+}
+
+func (f *RotatingFile) watchSignals() {
+	for {
+		select {
+		case <-f.signals:
+			_ = f.Reopen()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + compressSuffix)
+		return err
+	}
+	if err := errors.Join(gz.Close(), dst.Close()); err != nil {
+		_ = os.Remove(path + compressSuffix)
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
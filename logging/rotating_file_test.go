@@ -0,0 +1,241 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{MaxSize: 10})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+
+	f.Write([]byte("first-line\n"))
+	f.Write([]byte("second\n"))
+	if err := f.Close(); err != nil {
+		t.Errorf("Failed to close rotating file: %v", err)
+	}
+
+	if content := readFile(t, path); content != "second\n" {
+		t.Errorf("Expected current file to contain only the second write, got %q", content)
+	}
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 1 {
+		t.Fatalf("Expected 1 backup, got %v", backups)
+	}
+	if content := readFile(t, backups[0]); content != "first-line\n" {
+		t.Errorf("Expected backup to contain the first write, got %q", content)
+	}
+}
+
+func TestRotatingFileRotatesByInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{RotateInterval: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("old\n"))
+	time.Sleep(30 * time.Millisecond)
+	f.Write([]byte("new\n"))
+
+	if content := readFile(t, path); content != "new\n" {
+		t.Errorf("Expected file to be rotated after the interval, got %q", content)
+	}
+}
+
+func TestRotatingFileSkipsIdleInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{RotateInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	defer f.Close()
+
+	time.Sleep(time.Millisecond)
+	f.Write([]byte("first\n"))
+
+	if backups, _ := filepath.Glob(path + ".*"); len(backups) != 0 {
+		t.Errorf("Expected an empty file not to be rotated, got %v", backups)
+	}
+}
+
+func TestRotatingFileRapidRotations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		f.Write([]byte("line\n"))
+		if err := f.Rotate(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+	f.Close()
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 5 {
+		t.Fatalf("Expected 5 backups, got %v", backups)
+	}
+	for _, backup := range backups {
+		if content := readFile(t, backup); content != "line\n" {
+			t.Errorf("Expected backup %s to keep its line, got %q", backup, content)
+		}
+	}
+}
+
+func TestRotatingFileMaxBackupsAndCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		f.Write([]byte("line\n"))
+		if err := f.Rotate(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+	f.Close()
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups to be kept, got %v", backups)
+	}
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".gz") {
+			t.Errorf("Expected backup %s to be compressed", backup)
+			continue
+		}
+		file, _ := os.Open(backup)
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatalf("Failed to open gzip backup: %v", err)
+		}
+		data, _ := io.ReadAll(gz)
+		file.Close()
+		if string(data) != "line\n" {
+			t.Errorf("Expected compressed backup to contain 'line', got %q", data)
+		}
+	}
+}
+
+func TestRotatingFileMaxBackupsIgnoresForeignFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	foreign := []string{path + ".1", path + ".bak", path + ".2.gz"}
+	for _, name := range foreign {
+		if err := os.WriteFile(name, []byte("keep\n"), 0o644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	f, err := NewRotatingFile(path, FileConfig{MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		f.Write([]byte("line\n"))
+		if err := f.Rotate(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+	f.Close()
+
+	for _, name := range foreign {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("Expected %s not created by the writer to be kept: %v", name, err)
+		}
+	}
+	backups, _ := filepath.Glob(path + ".2*-*")
+	if len(backups) != 1 {
+		t.Errorf("Expected 1 rotated backup to be kept, got %v", backups)
+	}
+}
+
+func TestRotatingFileRenameFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{MaxSize: 10})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	defer f.Close()
+
+	renameFile = func(string, string) error { return syscall.EACCES }
+	f.Write([]byte("first-line\n"))
+	if _, err := f.Write([]byte("second\n")); !errors.Is(err, syscall.EACCES) {
+		t.Errorf("Expected the rename error to be reported, got %v", err)
+	}
+	renameFile = os.Rename
+
+	if content := readFile(t, path); content != "first-line\nsecond\n" {
+		t.Errorf("Expected writes to continue in the current file, got %q", content)
+	}
+
+	if _, err := f.Write([]byte("third\n")); err != nil {
+		t.Errorf("Expected rotation to succeed once renaming works, got %v", err)
+	}
+	if content := readFile(t, path); content != "third\n" {
+		t.Errorf("Expected a new file after rotation, got %q", content)
+	}
+}
+
+func TestRotatingFileWriteAfterClose(t *testing.T) {
+	f, err := NewRotatingFile(filepath.Join(t.TempDir(), "app.log"), FileConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	f.Close()
+
+	if _, err := f.Write([]byte("late\n")); err == nil {
+		t.Error("Expected write after close to fail")
+	}
+	if err := f.Reopen(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected reopen after close to fail with os.ErrClosed, got %v", err)
+	}
+	if f.file != nil {
+		f.file.Close()
+		t.Error("Expected reopen after close not to open the file")
+	}
+}
+
+func TestRotatingFileCloseTwice(t *testing.T) {
+	f, err := NewRotatingFile(filepath.Join(t.TempDir(), "app.log"), FileConfig{ReopenOnSIGHUP: true})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Errorf("Failed to close rotating file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("Expected second close to succeed, got %v", err)
+	}
+}
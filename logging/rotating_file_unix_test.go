@@ -0,0 +1,45 @@
+//go:build unix
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileReopenOnSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f, err := NewRotatingFile(path, FileConfig{ReopenOnSIGHUP: true})
+	if err != nil {
+		t.Fatalf("Failed to create rotating file: %v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("before\n"))
+
+	// Simulate logrotate moving the file away
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Failed to move log file: %v", err)
+	}
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected log file to be reopened after SIGHUP")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	f.Write([]byte("after\n"))
+	if content := readFile(t, path); content != "after\n" {
+		t.Errorf("Expected reopened file to contain new writes, got %q", content)
+	}
+}
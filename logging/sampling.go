@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var suppressedRecordsOpts = prometheus.CounterOpts{
+	Name: "log_records_suppressed_total",
+	Help: "Number of log records dropped by sampling",
+}
+
+// SamplingLimits controls how many similar records pass per interval
+type SamplingLimits struct {
+	// First records per message and level always pass in each interval
+	First int
+	// Thereafter passes every Thereafter-th record after First; zero drops the rest
+	Thereafter int
+}
+
+// SamplingConfig limits bursts of similar records. Records are similar when they
+// share a level and message.
+type SamplingConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	SamplingLimits
+	// Levels overrides the limits for specific levels
+	Levels map[slog.Level]SamplingLimits
+}
+
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+type samplingCounter struct {
+	seen       int
+	suppressed int
+}
+
+// sampler counts similar records and periodically reports what it suppressed.
+// It is shared by all handlers derived from the same samplingHandler.
+type sampler struct {
+	config     SamplingConfig
+	handler    slog.Handler
+	suppressed *prometheus.CounterVec
+
+	mu       sync.Mutex
+	counters map[samplingKey]*samplingCounter
+
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  sync.WaitGroup
+}
+
+func newSampler(config SamplingConfig, handler slog.Handler, suppressed *prometheus.CounterVec) *sampler {
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+
+	s := &sampler{
+		config:     config,
+		handler:    handler,
+		suppressed: suppressed,
+		counters:   make(map[samplingKey]*samplingCounter),
+		done:       make(chan struct{}),
+	}
+
+	s.stopped.Add(1)
+	go s.run()
+	return s
+}
+
+// allow reports whether a record passes and counts it if it does not
+func (s *sampler) allow(level slog.Level, message string) bool {
+	limits := s.config.SamplingLimits
+	if override, ok := s.config.Levels[level]; ok {
+		limits = override
+	}
+
+	s.mu.Lock()
+	key := samplingKey{level: level, message: message}
+	counter, ok := s.counters[key]
+	if !ok {
+		counter = &samplingCounter{}
+		s.counters[key] = counter
+	}
+	counter.seen++
+
+	n := counter.seen - limits.First
+	allowed := n <= 0 || (limits.Thereafter > 0 && n%limits.Thereafter == 0)
+	if !allowed {
+		counter.suppressed++
+	}
+	s.mu.Unlock()
+
+	if !allowed && s.suppressed != nil {
+		s.suppressed.WithLabelValues(level.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *sampler) run() {
+	defer s.stopped.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.done:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush starts a new interval and emits a summary for every suppressed message
+func (s *sampler) flush() {
+	s.mu.Lock()
+	counters := s.counters
+	s.counters = make(map[samplingKey]*samplingCounter, len(counters))
+	s.mu.Unlock()
+
+	for key, counter := range counters {
+		if counter.suppressed == 0 {
+			continue
+		}
+		record := slog.NewRecord(time.Now(), key.level, fmt.Sprintf("suppressed %d similar records", counter.suppressed), 0)
+		record.AddAttrs(
+			slog.String("sampled_message", key.message),
+			slog.Int("suppressed", counter.suppressed),
+			slog.Duration("interval", s.config.Interval),
+		)
+		if s.handler.Enabled(context.Background(), key.level) {
+			_ = s.handler.Handle(context.Background(), record)
+		}
+	}
+}
+
+// stop emits a final summary and stops the background goroutine
+func (s *sampler) stop(context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+	s.stopped.Wait()
+	return nil
+}
+
+// samplingHandler drops records beyond the sampling limits
+type samplingHandler struct {
+	slog.Handler
+	sampler *sampler
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.sampler.allow(record.Level, record.Message) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
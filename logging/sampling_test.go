@@ -0,0 +1,167 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Failed to decode record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSamplingFirstThenEveryNth(t *testing.T) {
+	var buf bytes.Buffer
+	s := newSampler(SamplingConfig{
+		Interval:       time.Hour,
+		SamplingLimits: SamplingLimits{First: 3, Thereafter: 5},
+	}, slog.NewJSONHandler(&buf, nil), nil)
+	logger := slog.New(&samplingHandler{Handler: slog.NewJSONHandler(&buf, nil), sampler: s})
+
+	for i := 0; i < 20; i++ {
+		logger.Info("storm", "i", i)
+	}
+	logger.Info("other")
+
+	// 3 first, then the 5th, 10th and 15th after them, plus "other"
+	if records := decodeRecords(t, &buf); len(records) != 7 {
+		t.Errorf("Expected 7 records, got %d: %s", len(records), buf.String())
+	}
+
+	buf.Reset()
+	if err := s.stop(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 summary record, got %d: %s", len(records), buf.String())
+	}
+	summary := records[0]
+	if summary["msg"] != "suppressed 14 similar records" {
+		t.Errorf("Expected summary message, got %v", summary["msg"])
+	}
+	if summary["sampled_message"] != "storm" {
+		t.Errorf("Expected sampled_message storm, got %v", summary["sampled_message"])
+	}
+	if summary["level"] != "INFO" {
+		t.Errorf("Expected summary at INFO, got %v", summary["level"])
+	}
+}
+
+func TestSamplingPerLevelLimits(t *testing.T) {
+	var buf bytes.Buffer
+	s := newSampler(SamplingConfig{
+		Interval:       time.Hour,
+		SamplingLimits: SamplingLimits{First: 1},
+		Levels: map[slog.Level]SamplingLimits{
+			slog.LevelError: {First: 10},
+		},
+	}, slog.NewJSONHandler(&buf, nil), nil)
+	defer s.stop(context.Background())
+	logger := slog.New(&samplingHandler{Handler: slog.NewJSONHandler(&buf, nil), sampler: s})
+
+	for i := 0; i < 5; i++ {
+		logger.Info("noisy")
+		logger.Error("failure")
+	}
+
+	var infos, errs int
+	for _, record := range decodeRecords(t, &buf) {
+		switch record["level"] {
+		case "INFO":
+			infos++
+		case "ERROR":
+			errs++
+		}
+	}
+	if infos != 1 {
+		t.Errorf("Expected 1 INFO record, got %d", infos)
+	}
+	if errs != 5 {
+		t.Errorf("Expected 5 ERROR records, got %d", errs)
+	}
+}
+
+func TestSamplingResetsEachInterval(t *testing.T) {
+	var buf bytes.Buffer
+	s := newSampler(SamplingConfig{
+		Interval:       time.Hour,
+		SamplingLimits: SamplingLimits{First: 1},
+	}, slog.NewJSONHandler(&buf, nil), nil)
+	defer s.stop(context.Background())
+	logger := slog.New(&samplingHandler{Handler: slog.NewJSONHandler(&buf, nil), sampler: s})
+
+	logger.Info("tick")
+	logger.Info("tick")
+	s.flush()
+	logger.Info("tick")
+
+	var ticks int
+	for _, record := range decodeRecords(t, &buf) {
+		if record["msg"] == "tick" {
+			ticks++
+		}
+	}
+	if ticks != 2 {
+		t.Errorf("Expected 2 tick records, got %d", ticks)
+	}
+}
+
+func TestSamplingSuppressedMetric(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+	defer ConfigureLogging(LoggingConfig{Level: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	err := ConfigureLogging(LoggingConfig{
+		Level:  slog.LevelInfo,
+		AsJSON: true,
+		Writer: &buf,
+		Sampling: SamplingConfig{
+			Enabled:        true,
+			Interval:       time.Hour,
+			SamplingLimits: SamplingLimits{First: 2},
+		},
+		Metrics: collector,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	logger := GetLogger("test")
+	for i := 0; i < 10; i++ {
+		logger.Warn("disk almost full")
+	}
+
+	counter := collector.CounterVec(suppressedRecordsOpts, []string{"level"})
+	if got := testutil.ToFloat64(counter.WithLabelValues("WARN")); got != 8 {
+		t.Errorf("Expected 8 suppressed records, got %v", got)
+	}
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "suppressed 8 similar records") {
+		t.Errorf("Expected summary record on shutdown, got %s", buf.String())
+	}
+}
@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceLayout selects how trace correlation fields are added to text and JSON records
+type TraceLayout string
+
+const (
+	// TraceLayoutNested adds a "span" map holding span_id and trace_id
+	TraceLayoutNested TraceLayout = "nested"
+	// TraceLayoutFlat adds trace_id and span_id as separate attributes
+	TraceLayoutFlat TraceLayout = "flat"
+	// TraceLayoutTraceparent adds a single W3C traceparent attribute
+	TraceLayoutTraceparent TraceLayout = "traceparent"
+)
+
+// TraceFieldsConfig controls the trace correlation fields of the text and json formats
+type TraceFieldsConfig struct {
+	// Layout is nested when empty
+	Layout TraceLayout
+	// Flags adds trace_flags and sampled
+	Flags bool
+	// GroupKey names the nested map; empty means "span"
+	GroupKey string
+	// TraceIDKey names the trace ID; empty means "trace_id"
+	TraceIDKey string
+	// SpanIDKey names the span ID; empty means "span_id"
+	SpanIDKey string
+	// TraceparentKey names the traceparent attribute; empty means "traceparent"
+	TraceparentKey string
+}
+
+func (c TraceFieldsConfig) validate() error {
+	switch TraceLayout(strings.ToLower(string(c.Layout))) {
+	case "", TraceLayoutNested, TraceLayoutFlat, TraceLayoutTraceparent:
+		return nil
+	default:
+		return fmt.Errorf("unsupported trace layout: %s", c.Layout)
+	}
+}
+
+// attrs returns the trace fields of spanContext in the configured layout
+func (c TraceFieldsConfig) attrs(spanContext trace.SpanContext) []slog.Attr {
+	traceID := spanContext.TraceID().String()
+	spanID := spanContext.SpanID().String()
+	flags := spanContext.TraceFlags().String()
+
+	var flagAttrs []slog.Attr
+	if c.Flags {
+		flagAttrs = []slog.Attr{
+			slog.String("trace_flags", flags),
+			slog.Bool("sampled", spanContext.IsSampled()),
+		}
+	}
+
+	switch TraceLayout(strings.ToLower(string(c.Layout))) {
+	case TraceLayoutFlat:
+		return append([]slog.Attr{
+			slog.String(keyOrDefault(c.TraceIDKey, "trace_id"), traceID),
+			slog.String(keyOrDefault(c.SpanIDKey, "span_id"), spanID),
+		}, flagAttrs...)
+	case TraceLayoutTraceparent:
+		traceparent := "00-" + traceID + "-" + spanID + "-" + flags
+		return append([]slog.Attr{
+			slog.String(keyOrDefault(c.TraceparentKey, "traceparent"), traceparent),
+		}, flagAttrs...)
+	default:
+		span := map[string]any{
+			keyOrDefault(c.SpanIDKey, "span_id"):   spanID,
+			keyOrDefault(c.TraceIDKey, "trace_id"): traceID,
+		}
+		for _, attr := range flagAttrs {
+			span[attr.Key] = attr.Value.Any()
+		}
+		return []slog.Attr{slog.Any(keyOrDefault(c.GroupKey, "span"), span)}
+	}
+}
+
+func keyOrDefault(key, fallback string) string {
+	if key == "" {
+		return fallback
+	}
+	return key
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func newTestTracingLogger(buf *bytes.Buffer, fields TraceFieldsConfig) *slog.Logger {
+	return slog.New(newLayoutHandler(slog.NewJSONHandler(buf, nil), "", fields.attrs))
+}
+
+func TestTraceFieldsNested(t *testing.T) {
+	var buf bytes.Buffer
+	// The span context is valid but not recording
+	newTestTracingLogger(&buf, TraceFieldsConfig{}).InfoContext(testSpanContext(t), "nested")
+
+	span, ok := decodeRecord(t, &buf)["span"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected span map, got %s", buf.String())
+	}
+	if span["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || span["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("Expected trace and span IDs, got %v", span)
+	}
+}
+
+func TestTraceFieldsFlat(t *testing.T) {
+	var buf bytes.Buffer
+	fields := TraceFieldsConfig{Layout: TraceLayoutFlat, Flags: true, TraceIDKey: "dd.trace_id"}
+	newTestTracingLogger(&buf, fields).InfoContext(testSpanContext(t), "flat")
+
+	record := decodeRecord(t, &buf)
+	for key, want := range map[string]any{
+		"dd.trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":     "00f067aa0ba902b7",
+		"trace_flags": "01",
+		"sampled":     true,
+	} {
+		if record[key] != want {
+			t.Errorf("Expected %s to be %v, got %v", key, want, record[key])
+		}
+	}
+	if _, ok := record["span"]; ok {
+		t.Error("Expected no span map in flat layout")
+	}
+}
+
+func TestTraceFieldsFlatInGroup(t *testing.T) {
+	for _, layout := range []TraceLayout{TraceLayoutFlat, TraceLayoutTraceparent} {
+		var buf bytes.Buffer
+		logger := newTestTracingLogger(&buf, TraceFieldsConfig{Layout: layout}).WithGroup("req").With("id", 7)
+		logger.InfoContext(testSpanContext(t), "grouped", "ms", 12)
+
+		record := decodeRecord(t, &buf)
+		req, ok := record["req"].(map[string]any)
+		if !ok || req["id"] != float64(7) || req["ms"] != float64(12) {
+			t.Errorf("Expected attributes nested under req, got %s", buf.String())
+		}
+		key := "trace_id"
+		if layout == TraceLayoutTraceparent {
+			key = "traceparent"
+		}
+		if _, ok := record[key]; !ok {
+			t.Errorf("Expected top-level %s with %s layout, got %s", key, layout, buf.String())
+		}
+		if _, ok := req[key]; ok {
+			t.Errorf("Expected no %s under req with %s layout, got %s", key, layout, buf.String())
+		}
+	}
+}
+
+func TestTraceFieldsTraceparent(t *testing.T) {
+	var buf bytes.Buffer
+	newTestTracingLogger(&buf, TraceFieldsConfig{Layout: TraceLayoutTraceparent}).InfoContext(testSpanContext(t), "w3c")
+
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if got := decodeRecord(t, &buf)["traceparent"]; got != want {
+		t.Errorf("Expected traceparent %s, got %v", want, got)
+	}
+}
+
+func TestTraceFieldsWithoutSpan(t *testing.T) {
+	var buf bytes.Buffer
+	newTestTracingLogger(&buf, TraceFieldsConfig{Layout: TraceLayoutFlat}).Info("no span")
+
+	if record := decodeRecord(t, &buf); record["trace_id"] != nil {
+		t.Errorf("Expected no trace fields, got %v", record)
+	}
+}
+
+func TestTraceFieldsUnsupportedLayout(t *testing.T) {
+	err := ConfigureLogging(LoggingConfig{
+		Writer:      &bytes.Buffer{},
+		Tracing:     true,
+		TraceFields: TraceFieldsConfig{Layout: "zipkin"},
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported layout")
+	}
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// GoMetricSet names a group of runtime/metrics exposed by the Go collector
+type GoMetricSet string
+
+const (
+	// GoMetricsGC adds GC metrics such as the go_gc_pauses_seconds histogram
+	GoMetricsGC GoMetricSet = "gc"
+	// GoMetricsMemory adds the go_memory_classes_* metrics
+	GoMetricsMemory GoMetricSet = "memory"
+	// GoMetricsScheduler adds scheduler metrics such as the go_sched_latencies_seconds histogram
+	GoMetricsScheduler GoMetricSet = "scheduler"
+	// GoMetricsDebug adds the go_godebug_* metrics
+	GoMetricsDebug GoMetricSet = "debug"
+	// GoMetricsAll adds every metric the Go runtime exposes
+	GoMetricsAll GoMetricSet = "all"
+)
+
+// CollectorOptions selects the built-in collectors registered by NewMetricsCollector.
+// They are registered without the namespace and const labels of the collector.
+type CollectorOptions struct {
+	// Go registers the Go runtime collector
+	Go bool
+	// GoMetricSets adds runtime/metrics based metrics to the Go collector
+	GoMetricSets []GoMetricSet
+	// GoMemStats keeps the go_memstats_* metrics, which GoMetricsMemory supersedes
+	GoMemStats bool
+
+	// Process registers the process collector, which reads /proc on Linux
+	Process bool
+	// ProcessNamespace prefixes the process metrics, e.g. "myapp" for myapp_process_cpu_seconds_total
+	ProcessNamespace string
+	// ProcessPidFn returns the PID of the process to collect metrics for; nil means this process
+	ProcessPidFn func() (int, error)
+	// ProcessReportErrors exposes collection errors instead of silently skipping metrics
+	ProcessReportErrors bool
+
+	// BuildInfo registers go_build_info with the main module's path, version and checksum
+	BuildInfo bool
+}
+
+// DefaultCollectorOptions registers the Go collector with its default metrics and the process collector
+func DefaultCollectorOptions() CollectorOptions {
+	return CollectorOptions{
+		Go:         true,
+		GoMemStats: true,
+		Process:    true,
+	}
+}
+
+// CollectorOptionsFromEnv creates CollectorOptions from environment variables
+func CollectorOptionsFromEnv() (CollectorOptions, error) {
+	var sets []GoMetricSet
+	for _, item := range strings.Split(getEnvOrDefault("METRICS_GO_METRIC_SETS", ""), ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item == "" {
+			continue
+		}
+		set := GoMetricSet(item)
+		if _, err := set.rule(); err != nil {
+			return CollectorOptions{}, err
+		}
+		sets = append(sets, set)
+	}
+
+	return CollectorOptions{
+		Go:               parseBool(getEnvOrDefault("METRICS_GO_COLLECTOR", "true")),
+		GoMetricSets:     sets,
+		GoMemStats:       parseBool(getEnvOrDefault("METRICS_GO_MEMSTATS", "true")),
+		Process:          parseBool(getEnvOrDefault("METRICS_PROCESS_COLLECTOR", "true")),
+		ProcessNamespace: getEnvOrDefault("METRICS_PROCESS_NAMESPACE", ""),
+		BuildInfo:        parseBool(getEnvOrDefault("METRICS_BUILD_INFO", "false")),
+	}, nil
+}
+
+func (s GoMetricSet) rule() (collectors.GoRuntimeMetricsRule, error) {
+	switch s {
+	case GoMetricsGC:
+		return collectors.MetricsGC, nil
+	case GoMetricsMemory:
+		return collectors.MetricsMemory, nil
+	case GoMetricsScheduler:
+		return collectors.MetricsScheduler, nil
+	case GoMetricsDebug:
+		return collectors.MetricsDebug, nil
+	case GoMetricsAll:
+		return collectors.MetricsAll, nil
+	default:
+		return collectors.GoRuntimeMetricsRule{}, fmt.Errorf("invalid Go metric set: %s", s)
+	}
+}
+
+// collectors builds the selected built-in collectors, panicking on an unknown Go metric set
+func (o CollectorOptions) collectors() []prometheus.Collector {
+	var builtins []prometheus.Collector
+
+	if o.Go {
+		rules := make([]collectors.GoRuntimeMetricsRule, 0, len(o.GoMetricSets))
+		for _, set := range o.GoMetricSets {
+			rule, err := set.rule()
+			if err != nil {
+				panic(err)
+			}
+			rules = append(rules, rule)
+		}
+
+		// The option type is internal to client_golang, so both options are always
+		// passed and an empty rule list leaves the defaults untouched
+		memStats := collectors.WithGoCollectorRuntimeMetrics()
+		if !o.GoMemStats {
+			memStats = collectors.WithGoCollectorMemStatsMetricsDisabled()
+		}
+		builtins = append(builtins, collectors.NewGoCollector(memStats, collectors.WithGoCollectorRuntimeMetrics(rules...)))
+	}
+
+	if o.Process {
+		builtins = append(builtins, collectors.NewProcessCollector(collectors.ProcessCollectorOpts{
+			PidFn:        o.ProcessPidFn,
+			Namespace:    o.ProcessNamespace,
+			ReportErrors: o.ProcessReportErrors,
+		}))
+	}
+
+	if o.BuildInfo {
+		builtins = append(builtins, collectors.NewBuildInfoCollector())
+	}
+	return builtins
+}
+
+func parseBool(value string) bool {
+	return strings.ToLower(value) == "true" || strings.ToLower(value) == "t"
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
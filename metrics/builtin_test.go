@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollectorOptionsFromEnvDefaults(t *testing.T) {
+	options, err := CollectorOptionsFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !options.Go || !options.GoMemStats || !options.Process {
+		t.Errorf("Expected Go and process collectors by default, got %+v", options)
+	}
+	if options.BuildInfo {
+		t.Error("Expected build info to be disabled by default")
+	}
+	if len(options.GoMetricSets) != 0 {
+		t.Errorf("Expected no Go metric sets, got %v", options.GoMetricSets)
+	}
+}
+
+func TestCollectorOptionsFromEnv(t *testing.T) {
+	os.Setenv("METRICS_GO_METRIC_SETS", "gc, Scheduler")
+	os.Setenv("METRICS_GO_MEMSTATS", "false")
+	os.Setenv("METRICS_PROCESS_COLLECTOR", "false")
+	os.Setenv("METRICS_PROCESS_NAMESPACE", "myapp")
+	os.Setenv("METRICS_BUILD_INFO", "true")
+	defer func() {
+		os.Unsetenv("METRICS_GO_METRIC_SETS")
+		os.Unsetenv("METRICS_GO_MEMSTATS")
+		os.Unsetenv("METRICS_PROCESS_COLLECTOR")
+		os.Unsetenv("METRICS_PROCESS_NAMESPACE")
+		os.Unsetenv("METRICS_BUILD_INFO")
+	}()
+
+	options, err := CollectorOptionsFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(options.GoMetricSets) != 2 || options.GoMetricSets[0] != GoMetricsGC || options.GoMetricSets[1] != GoMetricsScheduler {
+		t.Errorf("Expected gc and scheduler metric sets, got %v", options.GoMetricSets)
+	}
+	if options.GoMemStats {
+		t.Error("Expected MemStats metrics to be disabled")
+	}
+	if options.Process {
+		t.Error("Expected process collector to be disabled")
+	}
+	if options.ProcessNamespace != "myapp" {
+		t.Errorf("Expected process namespace myapp, got %s", options.ProcessNamespace)
+	}
+	if !options.BuildInfo {
+		t.Error("Expected build info to be enabled")
+	}
+}
+
+func TestCollectorOptionsFromEnvInvalidSet(t *testing.T) {
+	os.Setenv("METRICS_GO_METRIC_SETS", "gc,heap")
+	defer os.Unsetenv("METRICS_GO_METRIC_SETS")
+
+	if _, err := CollectorOptionsFromEnv(); err == nil {
+		t.Error("Expected an error for an unknown Go metric set")
+	}
+}
+
+func TestWithCollectorOptions(t *testing.T) {
+	collector := NewMetricsCollector(WithCollectorOptions(CollectorOptions{
+		Go:           true,
+		GoMetricSets: []GoMetricSet{GoMetricsScheduler},
+		BuildInfo:    true,
+	}))
+
+	count, err := testutil.GatherAndCount(collector.Registry(),
+		"go_sched_latencies_seconds", "go_build_info", "go_memstats_alloc_bytes", "process_start_time_seconds",
+	)
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	// Only the scheduler histogram and build info are expected
+	if count != 2 {
+		t.Errorf("Expected 2 metrics, got %d", count)
+	}
+}
+
+func TestWithCollectorOptionsProcess(t *testing.T) {
+	collector := NewMetricsCollector(WithCollectorOptions(CollectorOptions{
+		Process:          true,
+		ProcessNamespace: "myapp",
+		ProcessPidFn:     func() (int, error) { return os.Getpid(), nil },
+	}))
+
+	count, err := testutil.GatherAndCount(collector.Registry(), "myapp_process_start_time_seconds", "go_goroutines")
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected only the namespaced process metric, got %d", count)
+	}
+}
+
+func TestWithCollectorOptionsInvalidSet(t *testing.T) {
+	expectPanic(t, "an unknown Go metric set", func() {
+		NewMetricsCollector(WithCollectorOptions(CollectorOptions{Go: true, GoMetricSets: []GoMetricSet{"heap"}}))
+	})
+}
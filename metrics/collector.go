@@ -2,10 +2,11 @@
 package metrics
 
 import (
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/collectors"
 
 	"github.com/corruptmane/corrupt-o11y-go/metadata"
 )
@@ -13,29 +14,92 @@ import (
 // MetricsCollector provides a centralized registry for Prometheus metrics
 type MetricsCollector struct {
 	registry *prometheus.Registry
-	metrics  map[string]prometheus.Collector
-	mu       sync.RWMutex
+	// registerer applies the namespace and const labels of the options
+	registerer prometheus.Registerer
+	metrics    map[string]prometheus.Collector
+	mu         sync.RWMutex
 }
 
-// NewMetricsCollector creates a new metrics collector with built-in metrics
-func NewMetricsCollector() *MetricsCollector {
+// NewMetricsCollector creates a new metrics collector with the built-in metrics
+// selected by WithCollectorOptions, by default the Go and process metrics.
+// The namespace and const labels set by opts apply to metrics registered through
+// the collector, but not to the built-in Go and process metrics or service_info.
+func NewMetricsCollector(opts ...Option) *MetricsCollector {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	registry := prometheus.NewRegistry()
 
-	// Register built-in collectors
-	registry.MustRegister(collectors.NewGoCollector())
-	registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	builtins := DefaultCollectorOptions()
+	if o.builtins != nil {
+		builtins = *o.builtins
+	}
+	registry.MustRegister(builtins.collectors()...)
 
 	return &MetricsCollector{
-		registry: registry,
-		metrics:  make(map[string]prometheus.Collector),
+		registry:   registry,
+		registerer: o.registerer(registry),
+		metrics:    make(map[string]prometheus.Collector),
 	}
 }
 
-// Register registers a metric collector
+// Register registers a metric collector. Like the factory helpers it is tracked
+// by the fully-qualified name of its metric; name is only used for collectors
+// describing several metrics.
 func (mc *MetricsCollector) Register(name string, collector prometheus.Collector) error {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
+	if err := mc.registerer.Register(collector); err != nil {
+		return err
+	}
+
+	if fqName, ok := describedName(collector); ok {
+		name = fqName
+	}
+	mc.metrics[name] = collector
+	return nil
+}
+
+// describedName returns the fully-qualified name of the only metric described by
+// collector. prometheus.Desc has no accessor for it, so it is read from String.
+func describedName(collector prometheus.Collector) (string, bool) {
+	descs := make(chan *prometheus.Desc)
+	go func() {
+		collector.Describe(descs)
+		close(descs)
+	}()
+
+	var name string
+	var found, several bool
+	for desc := range descs {
+		quoted, ok := strings.CutPrefix(desc.String(), "Desc{fqName: ")
+		if !ok {
+			several = true
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(quoted)
+		if err != nil {
+			several = true
+			continue
+		}
+		descName, err := strconv.Unquote(quoted)
+		if err != nil || found && descName != name {
+			several = true
+			continue
+		}
+		name, found = descName, true
+	}
+	return name, found && !several
+}
+
+// registerUnwrapped registers a collector without the namespace and const labels
+func (mc *MetricsCollector) registerUnwrapped(name string, collector prometheus.Collector) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
 	if err := mc.registry.Register(collector); err != nil {
 		return err
 	}
@@ -50,13 +114,21 @@ func (mc *MetricsCollector) Unregister(name string) bool {
 	defer mc.mu.Unlock()
 
 	if collector, exists := mc.metrics[name]; exists {
-		mc.registry.Unregister(collector)
+		mc.unregister(collector)
 		delete(mc.metrics, name)
 		return true
 	}
 	return false
 }
 
+// unregister removes collector whether or not it was registered with the namespace
+// and const labels
+func (mc *MetricsCollector) unregister(collector prometheus.Collector) {
+	if !mc.registerer.Unregister(collector) {
+		mc.registry.Unregister(collector)
+	}
+}
+
 // Registry returns the underlying Prometheus registry
 func (mc *MetricsCollector) Registry() *prometheus.Registry {
 	return mc.registry
@@ -68,18 +140,19 @@ func (mc *MetricsCollector) Clear() {
 	defer mc.mu.Unlock()
 
 	for name, collector := range mc.metrics {
-		mc.registry.Unregister(collector)
+		mc.unregister(collector)
 		delete(mc.metrics, name)
 	}
 }
 
-// CreateServiceInfoMetric creates a service info metric using this collector's registry
+// CreateServiceInfoMetric creates a service info metric using this collector's
+// registry, without the collector's namespace and const labels
 func (mc *MetricsCollector) CreateServiceInfoMetric(
 	serviceName, serviceVersion, instanceID string,
 	commitSHA, buildTime *string,
 ) *prometheus.GaugeVec {
 	metric := CreateServiceInfoMetric(serviceName, serviceVersion, instanceID, commitSHA, buildTime)
-	_ = mc.Register("service_info", metric)
+	_ = mc.registerUnwrapped("service_info", metric)
 	return metric
 }
 
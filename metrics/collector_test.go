@@ -52,6 +52,24 @@ func TestRegisterUnregister(t *testing.T) {
 	}
 }
 
+func TestRegisterTracksFullyQualifiedName(t *testing.T) {
+	collector := NewMetricsCollector()
+
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Namespace: "app", Name: "jobs_total", Help: "Jobs"})
+	if err := collector.Register("jobs", counter); err != nil {
+		t.Fatalf("Failed to register counter: %v", err)
+	}
+	// A factory metric whose name matches the one passed above must not replace it
+	collector.Gauge(prometheus.GaugeOpts{Name: "jobs", Help: "Jobs"})
+
+	if !collector.Unregister("app_jobs_total") {
+		t.Error("Expected counter to be tracked by its fully-qualified name")
+	}
+	if !collector.Unregister("jobs") {
+		t.Error("Expected gauge to still be tracked")
+	}
+}
+
 func TestClear(t *testing.T) {
 	collector := NewMetricsCollector()
 
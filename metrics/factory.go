@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Counter creates and registers a counter. Registering a counter identical to an
+// existing one returns the existing counter; a conflicting one panics.
+func (mc *MetricsCollector) Counter(opts prometheus.CounterOpts) prometheus.Counter {
+	return must(mc.RegisterCounter(opts))
+}
+
+// RegisterCounter is like Counter but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterCounter(opts prometheus.CounterOpts) (prometheus.Counter, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewCounter(opts))
+}
+
+// CounterVec creates and registers a counter vector, see Counter
+func (mc *MetricsCollector) CounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.CounterVec {
+	return must(mc.RegisterCounterVec(opts, labelNames))
+}
+
+// RegisterCounterVec is like CounterVec but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterCounterVec(opts prometheus.CounterOpts, labelNames []string) (*prometheus.CounterVec, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewCounterVec(opts, labelNames))
+}
+
+// Gauge creates and registers a gauge, see Counter
+func (mc *MetricsCollector) Gauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	return must(mc.RegisterGauge(opts))
+}
+
+// RegisterGauge is like Gauge but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterGauge(opts prometheus.GaugeOpts) (prometheus.Gauge, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewGauge(opts))
+}
+
+// GaugeVec creates and registers a gauge vector, see Counter
+func (mc *MetricsCollector) GaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec {
+	return must(mc.RegisterGaugeVec(opts, labelNames))
+}
+
+// RegisterGaugeVec is like GaugeVec but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterGaugeVec(opts prometheus.GaugeOpts, labelNames []string) (*prometheus.GaugeVec, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewGaugeVec(opts, labelNames))
+}
+
+// Histogram creates and registers a histogram, see Counter
+func (mc *MetricsCollector) Histogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	return must(mc.RegisterHistogram(opts))
+}
+
+// RegisterHistogram is like Histogram but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterHistogram(opts prometheus.HistogramOpts) (prometheus.Histogram, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewHistogram(opts))
+}
+
+// HistogramVec creates and registers a histogram vector, see Counter
+func (mc *MetricsCollector) HistogramVec(opts prometheus.HistogramOpts, labelNames []string) *prometheus.HistogramVec {
+	return must(mc.RegisterHistogramVec(opts, labelNames))
+}
+
+// RegisterHistogramVec is like HistogramVec but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterHistogramVec(opts prometheus.HistogramOpts, labelNames []string) (*prometheus.HistogramVec, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewHistogramVec(opts, labelNames))
+}
+
+// Summary creates and registers a summary, see Counter
+func (mc *MetricsCollector) Summary(opts prometheus.SummaryOpts) prometheus.Summary {
+	return must(mc.RegisterSummary(opts))
+}
+
+// RegisterSummary is like Summary but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterSummary(opts prometheus.SummaryOpts) (prometheus.Summary, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewSummary(opts))
+}
+
+// SummaryVec creates and registers a summary vector, see Counter
+func (mc *MetricsCollector) SummaryVec(opts prometheus.SummaryOpts, labelNames []string) *prometheus.SummaryVec {
+	return must(mc.RegisterSummaryVec(opts, labelNames))
+}
+
+// RegisterSummaryVec is like SummaryVec but returns an error for a conflicting metric
+func (mc *MetricsCollector) RegisterSummaryVec(opts prometheus.SummaryOpts, labelNames []string) (*prometheus.SummaryVec, error) {
+	return register(mc, fqName(opts.Namespace, opts.Subsystem, opts.Name), prometheus.NewSummaryVec(opts, labelNames))
+}
+
+// register adds collector to the registry under its fully-qualified metric name.
+// An identical metric that is already registered is returned instead.
+func register[T prometheus.Collector](mc *MetricsCollector, name string, collector T) (T, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if err := mc.registerer.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(T); ok && sameMetricType(existing, collector) {
+				return existing, nil
+			}
+			err = errors.New("a metric of a different type is already registered")
+		}
+		var zero T
+		return zero, fmt.Errorf("failed to register metric %s: %w", name, err)
+	}
+
+	mc.metrics[name] = collector
+	return collector, nil
+}
+
+// must panics on a registration error like prometheus.MustRegister
+func must[T any](metric T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return metric
+}
+
+// sameMetricType tells apart plain metrics whose interfaces overlap, such as a
+// gauge that also satisfies prometheus.Counter. Vectors are distinguished by type.
+func sameMetricType(existing, created prometheus.Collector) bool {
+	existingMetric, ok := existing.(prometheus.Metric)
+	if !ok {
+		return true
+	}
+	createdMetric, ok := created.(prometheus.Metric)
+	if !ok {
+		return false
+	}
+
+	var a, b dto.Metric
+	if existingMetric.Write(&a) != nil || createdMetric.Write(&b) != nil {
+		return false
+	}
+	return (a.Counter != nil) == (b.Counter != nil) &&
+		(a.Gauge != nil) == (b.Gauge != nil) &&
+		(a.Histogram != nil) == (b.Histogram != nil) &&
+		(a.Summary != nil) == (b.Summary != nil)
+}
+
+func fqName(namespace, subsystem, name string) string {
+	return prometheus.BuildFQName(namespace, subsystem, name)
+}
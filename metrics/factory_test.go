@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func expectPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected %s to panic", name)
+		}
+	}()
+	f()
+}
+
+func TestFactoryHelpers(t *testing.T) {
+	collector := NewMetricsCollector()
+
+	collector.Counter(prometheus.CounterOpts{Name: "jobs_total", Help: "Jobs"}).Inc()
+	collector.CounterVec(prometheus.CounterOpts{Name: "requests_total", Help: "Requests"}, []string{"code"}).WithLabelValues("200").Inc()
+	collector.Gauge(prometheus.GaugeOpts{Name: "workers", Help: "Workers"}).Set(3)
+	collector.GaugeVec(prometheus.GaugeOpts{Name: "queue_size", Help: "Queue size"}, []string{"queue"}).WithLabelValues("a").Set(1)
+	collector.Histogram(prometheus.HistogramOpts{Name: "latency_seconds", Help: "Latency"}).Observe(0.1)
+	collector.HistogramVec(prometheus.HistogramOpts{Name: "size_bytes", Help: "Size"}, []string{"kind"}).WithLabelValues("x").Observe(10)
+	collector.Summary(prometheus.SummaryOpts{Name: "duration_seconds", Help: "Duration"}).Observe(1)
+	collector.SummaryVec(prometheus.SummaryOpts{Namespace: "app", Name: "wait_seconds", Help: "Wait"}, []string{"op"}).WithLabelValues("y").Observe(2)
+
+	count, err := testutil.GatherAndCount(collector.Registry(),
+		"jobs_total", "requests_total", "workers", "queue_size",
+		"latency_seconds", "size_bytes", "duration_seconds", "app_wait_seconds",
+	)
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	if count != 8 {
+		t.Errorf("Expected 8 metrics, got %d", count)
+	}
+
+	// Metrics are tracked by their fully-qualified name
+	if !collector.Unregister("app_wait_seconds") {
+		t.Error("Expected Unregister to find app_wait_seconds")
+	}
+}
+
+func TestFactoryHelpersReturnExisting(t *testing.T) {
+	collector := NewMetricsCollector()
+	opts := prometheus.CounterOpts{Name: "events_total", Help: "Events"}
+
+	first := collector.CounterVec(opts, []string{"type"})
+	second := collector.CounterVec(opts, []string{"type"})
+	if first != second {
+		t.Error("Expected identical registration to return the existing counter")
+	}
+
+	second.WithLabelValues("a").Inc()
+	if got := testutil.ToFloat64(first.WithLabelValues("a")); got != 1 {
+		t.Errorf("Expected shared counter value 1, got %v", got)
+	}
+
+	gauge := collector.Gauge(prometheus.GaugeOpts{Name: "temperature", Help: "Temperature"})
+	if collector.Gauge(prometheus.GaugeOpts{Name: "temperature", Help: "Temperature"}) != gauge {
+		t.Error("Expected identical registration to return the existing gauge")
+	}
+}
+
+func TestFactoryHelpersConflicts(t *testing.T) {
+	collector := NewMetricsCollector()
+	collector.CounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Events"}, []string{"type"})
+	collector.Gauge(prometheus.GaugeOpts{Name: "temperature", Help: "Temperature"})
+
+	expectPanic(t, "different help", func() {
+		collector.CounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Other"}, []string{"type"})
+	})
+	expectPanic(t, "different labels", func() {
+		collector.CounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Events"}, []string{"kind"})
+	})
+	expectPanic(t, "different type", func() {
+		collector.Counter(prometheus.CounterOpts{Name: "temperature", Help: "Temperature"})
+	})
+}
+
+func TestFactoryRegisterVariants(t *testing.T) {
+	collector := NewMetricsCollector()
+	existing := collector.CounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Events"}, []string{"type"})
+
+	same, err := collector.RegisterCounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Events"}, []string{"type"})
+	if err != nil || same != existing {
+		t.Errorf("Expected the existing counter without error, got %v", err)
+	}
+
+	conflicting, err := collector.RegisterCounterVec(prometheus.CounterOpts{Name: "events_total", Help: "Other"}, []string{"type"})
+	if err == nil || conflicting != nil {
+		t.Error("Expected an error for a conflicting counter")
+	}
+	if _, err := collector.RegisterGauge(prometheus.GaugeOpts{Name: "events_total", Help: "Events"}); err == nil {
+		t.Error("Expected an error for a metric of a different type")
+	}
+}
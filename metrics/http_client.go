@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans started by this package
+const instrumentationName = "github.com/corruptmane/corrupt-o11y-go/metrics"
+
+var (
+	httpClientRequestsOpts = prometheus.CounterOpts{
+		Name: "http_client_requests_total",
+		Help: "Number of outbound HTTP requests by method, host and status class",
+	}
+	httpClientDurationOpts = prometheus.HistogramOpts{
+		Name: "http_client_request_duration_seconds",
+		Help: "Time until the response headers of outbound HTTP requests arrived by method, host and status class",
+	}
+	httpClientPhaseOpts = prometheus.HistogramOpts{
+		Name: "http_client_phase_duration_seconds",
+		Help: "Duration of the dns, connect, tls and first_byte phases of outbound HTTP requests by host",
+	}
+	httpClientErrorsOpts = prometheus.CounterOpts{
+		Name: "http_client_request_errors_total",
+		Help: "Number of outbound HTTP requests that failed without a response by method, host and reason",
+	}
+)
+
+// HTTPClientOptions configures HTTPClientTransport
+type HTTPClientOptions struct {
+	// Host returns the host label of a request; by default the URL host, including any port
+	Host func(*http.Request) string
+	// Buckets are the histogram buckets in seconds; nil means prometheus.DefBuckets
+	Buckets []float64
+	// TracerProvider starts the client spans; nil means the global provider
+	TracerProvider trace.TracerProvider
+	// Propagator injects the trace context into request headers; nil means the global propagator
+	Propagator propagation.TextMapPropagator
+}
+
+type httpClientTransport struct {
+	base       http.RoundTripper
+	requests   *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	phases     *prometheus.HistogramVec
+	errors     *prometheus.CounterVec
+	host       func(*http.Request) string
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// HTTPClientTransport wraps base, or http.DefaultTransport when nil, recording
+// request counts, latencies, connection phase timings and errors of outbound
+// requests on collector. Each request runs in a client span whose context is
+// injected into the request headers, so it continues the trace downstream once
+// tracing is configured.
+func HTTPClientTransport(collector *MetricsCollector, base http.RoundTripper, opts HTTPClientOptions) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	buckets := opts.Buckets
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+	durationOpts := httpClientDurationOpts
+	durationOpts.Buckets = buckets
+	phaseOpts := httpClientPhaseOpts
+	phaseOpts.Buckets = buckets
+
+	t := &httpClientTransport{
+		base:       base,
+		requests:   collector.CounterVec(httpClientRequestsOpts, []string{"method", "host", "status_class"}),
+		duration:   collector.HistogramVec(durationOpts, []string{"method", "host", "status_class"}),
+		phases:     collector.HistogramVec(phaseOpts, []string{"host", "phase"}),
+		errors:     collector.CounterVec(httpClientErrorsOpts, []string{"method", "host", "reason"}),
+		host:       opts.Host,
+		propagator: opts.Propagator,
+	}
+	if t.host == nil {
+		t.host = func(r *http.Request) string { return r.URL.Host }
+	}
+
+	tracerProvider := opts.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	t.tracer = tracerProvider.Tracer(instrumentationName)
+	return t
+}
+
+func (t *httpClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := normalizeMethod(req.Method)
+	host := t.host(req)
+
+	ctx, span := t.tracer.Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.Redacted()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		span.SetAttributes(semconv.ServerPort(port))
+	}
+
+	start := time.Now()
+	ctx = httptrace.WithClientTrace(ctx, t.clientTrace(host, start))
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(ctx)
+	propagator := t.propagator
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Seconds()
+	exemplar := traceExemplar(ctx)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.errors.WithLabelValues(method, host, errorReason(err)).Inc()
+		addWithExemplar(t.requests.WithLabelValues(method, host, "error"), 1, exemplar)
+		observeWithExemplar(t.duration.WithLabelValues(method, host, "error"), elapsed, exemplar)
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	class := statusClass(resp.StatusCode)
+	addWithExemplar(t.requests.WithLabelValues(method, host, class), 1, exemplar)
+	observeWithExemplar(t.duration.WithLabelValues(method, host, class), elapsed, exemplar)
+	return resp, nil
+}
+
+// clientTrace observes the phases of a request. Connection attempts to several
+// addresses may run concurrently; each successful one is observed.
+func (t *httpClientTransport) clientTrace(host string, start time.Time) *httptrace.ClientTrace {
+	var dnsStart, tlsStart time.Time
+	connectStarts := make(map[string]time.Time)
+	var mu sync.Mutex
+
+	observe := func(phase string, since time.Time) {
+		t.phases.WithLabelValues(host, phase).Observe(time.Since(since).Seconds())
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			mu.Lock()
+			dnsStart = time.Now()
+			mu.Unlock()
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			if info.Err == nil && !dnsStart.IsZero() {
+				observe("dns", dnsStart)
+			}
+		},
+		ConnectStart: func(network, addr string) {
+			mu.Lock()
+			connectStarts[network+"/"+addr] = time.Now()
+			mu.Unlock()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if started, ok := connectStarts[network+"/"+addr]; ok && err == nil {
+				observe("connect", started)
+			}
+		},
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			tlsStart = time.Now()
+			mu.Unlock()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil && !tlsStart.IsZero() {
+				observe("tls", tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() {
+			observe("first_byte", start)
+		},
+	}
+}
+
+// errorReason classifies a transport error into a bounded label value
+func errorReason(err error) string {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var opErr *net.OpError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &dnsErr):
+		return "dns"
+	case errors.As(err, &certErr), errors.As(err, &recordErr):
+		return "tls"
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return "connect"
+	default:
+		return "other"
+	}
+}
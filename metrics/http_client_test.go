@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestClient(collector *MetricsCollector, recorder *tracetest.SpanRecorder) *http.Client {
+	return &http.Client{Transport: HTTPClientTransport(collector, nil, HTTPClientOptions{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		Propagator:     propagation.TraceContext{},
+	})}
+}
+
+func TestHTTPClientTransport(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	collector := NewMetricsCollector()
+	recorder := tracetest.NewSpanRecorder()
+	client := newTestClient(collector, recorder)
+
+	for _, path := range []string{"/ok", "/ok", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	expected := `
+# HELP http_client_requests_total Number of outbound HTTP requests by method, host and status class
+# TYPE http_client_requests_total counter
+http_client_requests_total{host="` + host + `",method="GET",status_class="2xx"} 2
+http_client_requests_total{host="` + host + `",method="GET",status_class="4xx"} 1
+`
+	if err := testutil.GatherAndCompare(collector.Registry(), strings.NewReader(expected), "http_client_requests_total"); err != nil {
+		t.Errorf("Unexpected metrics: %v", err)
+	}
+
+	if histogram(t, collector, "http_client_phase_duration_seconds", "phase", "connect") == nil {
+		t.Error("Expected the connect phase to be observed")
+	}
+	if phase := histogram(t, collector, "http_client_phase_duration_seconds", "phase", "first_byte"); phase == nil || phase.GetSampleCount() != 3 {
+		t.Errorf("Expected 3 first_byte observations, got %v", phase)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+	if spans[0].SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected a client span, got %v", spans[0].SpanKind())
+	}
+	if spans[2].Status().Code != codes.Error {
+		t.Errorf("Expected a 404 to mark the span as failed, got %v", spans[2].Status().Code)
+	}
+	if !strings.Contains(traceparent, spans[2].SpanContext().TraceID().String()) {
+		t.Errorf("Expected traceparent with trace ID %s, got %q", spans[2].SpanContext().TraceID(), traceparent)
+	}
+}
+
+func TestHTTPClientTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	collector := NewMetricsCollector()
+	recorder := tracetest.NewSpanRecorder()
+	client := newTestClient(collector, recorder)
+
+	if _, err := client.Get(url); err == nil {
+		t.Fatal("Expected the request to a closed server to fail")
+	}
+
+	host := strings.TrimPrefix(url, "http://")
+	errorsTotal := collector.CounterVec(httpClientErrorsOpts, []string{"method", "host", "reason"})
+	if value := testutil.ToFloat64(errorsTotal.WithLabelValues("GET", host, "connect")); value != 1 {
+		t.Errorf("Expected 1 connect error, got %v", value)
+	}
+	requests := collector.CounterVec(httpClientRequestsOpts, []string{"method", "host", "status_class"})
+	if value := testutil.ToFloat64(requests.WithLabelValues("GET", host, "error")); value != 1 {
+		t.Errorf("Expected 1 failed request, got %v", value)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != codes.Error {
+		t.Error("Expected a failed span")
+	}
+}
+
+func TestHTTPClientTransportHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	collector := NewMetricsCollector()
+	client := &http.Client{Transport: HTTPClientTransport(collector, nil, HTTPClientOptions{
+		Host:           func(*http.Request) string { return "users-api" },
+		TracerProvider: sdktrace.NewTracerProvider(),
+		Propagator:     propagation.TraceContext{},
+	})}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if req.Header.Get("traceparent") != "" {
+		t.Error("Expected the original request to be left unmodified")
+	}
+	requests := collector.CounterVec(httpClientRequestsOpts, []string{"method", "host", "status_class"})
+	if value := testutil.ToFloat64(requests.WithLabelValues("GET", "users-api", "2xx")); value != 1 {
+		t.Errorf("Expected 1 request to users-api, got %v", value)
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{context.Canceled, "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{errors.New("boom"), "other"},
+	}
+	for _, tt := range tests {
+		if reason := errorReason(tt.err); reason != tt.expected {
+			t.Errorf("Expected %s for %v, got %s", tt.expected, tt.err, reason)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unmatchedRoute labels requests for which no route template is known, such as
+// requests answered by ServeMux with 404
+const unmatchedRoute = "unmatched"
+
+// sizeBuckets cover request and response bodies from 100B to 100MB
+var sizeBuckets = prometheus.ExponentialBuckets(100, 10, 7)
+
+var (
+	httpServerRequestsOpts = prometheus.CounterOpts{
+		Name: "http_server_requests_total",
+		Help: "Number of HTTP requests handled by method, route and status class",
+	}
+	httpServerDurationOpts = prometheus.HistogramOpts{
+		Name: "http_server_request_duration_seconds",
+		Help: "Duration of HTTP requests by method, route and status class",
+	}
+	httpServerInFlightOpts = prometheus.GaugeOpts{
+		Name: "http_server_requests_in_flight",
+		Help: "Number of HTTP requests being handled by method",
+	}
+	httpServerRequestSizeOpts = prometheus.HistogramOpts{
+		Name:    "http_server_request_size_bytes",
+		Help:    "Size of HTTP request bodies by method and route",
+		Buckets: sizeBuckets,
+	}
+	httpServerResponseSizeOpts = prometheus.HistogramOpts{
+		Name:    "http_server_response_size_bytes",
+		Help:    "Size of HTTP response bodies by method, route and status class",
+		Buckets: sizeBuckets,
+	}
+)
+
+// HTTPServerOptions configures HTTPServerMiddleware
+type HTTPServerOptions struct {
+	// Route returns the route template of a request after it was served. By
+	// default it is the ServeMux pattern without its method, e.g. "/users/{id}".
+	Route func(*http.Request) string
+	// Buckets are the duration histogram buckets in seconds; nil means prometheus.DefBuckets
+	Buckets []float64
+}
+
+type httpServerMetrics struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	inFlight     *prometheus.GaugeVec
+	requestSize  *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+	route        func(*http.Request) string
+}
+
+// HTTPServerMiddleware records request count, duration, in-flight requests and
+// request and response sizes, registered on collector. Requests are labeled by
+// method, route template and status class ("2xx") to keep cardinality bounded.
+//
+// The default route comes from Request.Pattern, which ServeMux sets when it is
+// the handler wrapped directly. Durations and counts carry the trace ID as an
+// exemplar when the request context has a sampled span, so the middleware should
+// run inside the tracing middleware.
+func HTTPServerMiddleware(collector *MetricsCollector, opts HTTPServerOptions) func(http.Handler) http.Handler {
+	durationOpts := httpServerDurationOpts
+	durationOpts.Buckets = opts.Buckets
+	if durationOpts.Buckets == nil {
+		durationOpts.Buckets = prometheus.DefBuckets
+	}
+
+	m := &httpServerMetrics{
+		requests:     collector.CounterVec(httpServerRequestsOpts, []string{"method", "route", "status_class"}),
+		duration:     collector.HistogramVec(durationOpts, []string{"method", "route", "status_class"}),
+		inFlight:     collector.GaugeVec(httpServerInFlightOpts, []string{"method"}),
+		requestSize:  collector.HistogramVec(httpServerRequestSizeOpts, []string{"method", "route"}),
+		responseSize: collector.HistogramVec(httpServerResponseSizeOpts, []string{"method", "route", "status_class"}),
+		route:        opts.Route,
+	}
+	if m.route == nil {
+		m.route = patternRoute
+	}
+	return m.middleware
+}
+
+func (m *httpServerMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := normalizeMethod(r.Method)
+		inFlight := m.inFlight.WithLabelValues(method)
+		inFlight.Inc()
+
+		body := &countingBody{ReadCloser: r.Body}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = body
+		}
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+
+		defer func() {
+			inFlight.Dec()
+
+			// A panicking handler that has not written a response results in a 500
+			panicked := recover()
+			if panicked != nil && !rw.wroteHeader {
+				rw.status = http.StatusInternalServerError
+			}
+
+			route := m.route(r)
+			if route == "" {
+				route = unmatchedRoute
+			}
+			class := statusClass(rw.status)
+			exemplar := traceExemplar(r.Context())
+
+			addWithExemplar(m.requests.WithLabelValues(method, route, class), 1, exemplar)
+			observeWithExemplar(m.duration.WithLabelValues(method, route, class), time.Since(start).Seconds(), exemplar)
+
+			requestSize := body.n
+			if requestSize == 0 && r.ContentLength > 0 {
+				requestSize = r.ContentLength
+			}
+			m.requestSize.WithLabelValues(method, route).Observe(float64(requestSize))
+			m.responseSize.WithLabelValues(method, route, class).Observe(float64(rw.written))
+
+			if panicked != nil {
+				panic(panicked)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// patternRoute returns the ServeMux pattern of r without its method
+func patternRoute(r *http.Request) string {
+	pattern := r.Pattern
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimSpace(path)
+	}
+	return pattern
+}
+
+// normalizeMethod keeps the standard methods and maps anything else to "OTHER"
+func normalizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "OTHER"
+}
+
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// traceExemplar returns exemplar labels for the sampled span in ctx, if any
+func traceExemplar(ctx context.Context) prometheus.Labels {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{"trace_id": spanContext.TraceID().String()}
+}
+
+func addWithExemplar(counter prometheus.Counter, value float64, exemplar prometheus.Labels) {
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok && exemplar != nil {
+		adder.AddWithExemplar(value, exemplar)
+		return
+	}
+	counter.Add(value)
+}
+
+func observeWithExemplar(observer prometheus.Observer, value float64, exemplar prometheus.Labels) {
+	if eo, ok := observer.(prometheus.ExemplarObserver); ok && exemplar != nil {
+		eo.ObserveWithExemplar(value, exemplar)
+		return
+	}
+	observer.Observe(value)
+}
+
+// countingBody counts the bytes of a request body read by the handler
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// responseWriter records the status code and body size of a response. Unwrap
+// lets http.ResponseController reach the Flusher and Hijacker of the original,
+// and Hijack and ReadFrom serve handlers that type-assert for them.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	// Informational responses are followed by the final one
+	if !w.wroteHeader && status >= 200 {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	w.wroteHeader = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack lets handlers such as websocket upgraders take over the connection. A
+// hijacked request is recorded as 101 Switching Protocols.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil && !w.wroteHeader {
+		w.status = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return conn, buf, err
+}
+
+// ReadFrom keeps the sendfile path of the original ResponseWriter, as used by
+// io.Copy and http.ServeContent
+func (w *responseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.wroteHeader = true
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	w.written += n
+	return n, err
+}
@@ -0,0 +1,210 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusServiceUnavailable)
+	})
+	return mux
+}
+
+func TestHTTPServerMiddleware(t *testing.T) {
+	collector := NewMetricsCollector()
+	handler := HTTPServerMiddleware(collector, HTTPServerOptions{})(newTestMux())
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("0123456789")))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PURGE", "/users/1", nil))
+
+	expected := `
+# HELP http_server_requests_total Number of HTTP requests handled by method, route and status class
+# TYPE http_server_requests_total counter
+http_server_requests_total{method="GET",route="/fail",status_class="5xx"} 1
+http_server_requests_total{method="GET",route="/users/{id}",status_class="2xx"} 2
+http_server_requests_total{method="GET",route="unmatched",status_class="4xx"} 1
+http_server_requests_total{method="OTHER",route="unmatched",status_class="4xx"} 1
+http_server_requests_total{method="POST",route="/users",status_class="2xx"} 1
+`
+	if err := testutil.GatherAndCompare(collector.Registry(), strings.NewReader(expected), "http_server_requests_total"); err != nil {
+		t.Errorf("Unexpected metrics: %v", err)
+	}
+
+	if count := testutil.CollectAndCount(collector.Registry(), "http_server_request_duration_seconds"); count != 5 {
+		t.Errorf("Expected 5 duration series, got %d", count)
+	}
+	if value := testutil.ToFloat64(collector.GaugeVec(httpServerInFlightOpts, []string{"method"}).WithLabelValues("GET")); value != 0 {
+		t.Errorf("Expected no requests in flight, got %v", value)
+	}
+
+	requestSize := histogram(t, collector, "http_server_request_size_bytes", "route", "/users")
+	if requestSize.GetSampleSum() != 10 {
+		t.Errorf("Expected request size 10, got %v", requestSize.GetSampleSum())
+	}
+	responseSize := histogram(t, collector, "http_server_response_size_bytes", "route", "/users/{id}")
+	if responseSize.GetSampleSum() != 10 || responseSize.GetSampleCount() != 2 {
+		t.Errorf("Expected 2 responses of 5 bytes, got %d totalling %v", responseSize.GetSampleCount(), responseSize.GetSampleSum())
+	}
+}
+
+func TestHTTPServerMiddlewareRoute(t *testing.T) {
+	collector := NewMetricsCollector()
+	handler := HTTPServerMiddleware(collector, HTTPServerOptions{
+		Route: func(*http.Request) string { return "custom" },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/anything", nil))
+
+	if count := testutil.CollectAndCount(collector.CounterVec(httpServerRequestsOpts, []string{"method", "route", "status_class"})); count != 1 {
+		t.Fatalf("Expected one series, got %d", count)
+	}
+	if histogram(t, collector, "http_server_request_duration_seconds", "route", "custom") == nil {
+		t.Error("Expected the route from the extractor")
+	}
+}
+
+func TestHTTPServerMiddlewarePanic(t *testing.T) {
+	collector := NewMetricsCollector()
+	handler := HTTPServerMiddleware(collector, HTTPServerOptions{})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	expectPanic(t, "the handler", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	if histogram(t, collector, "http_server_request_duration_seconds", "status_class", "5xx") == nil {
+		t.Error("Expected a panicking handler to be recorded as 5xx")
+	}
+}
+
+func TestHTTPServerMiddlewareExemplar(t *testing.T) {
+	collector := NewMetricsCollector()
+	handler := HTTPServerMiddleware(collector, HTTPServerOptions{})(newTestMux())
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil).WithContext(ctx))
+
+	families, err := collector.Registry().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "http_server_requests_total" {
+			continue
+		}
+		exemplar := family.GetMetric()[0].GetCounter().GetExemplar()
+		if exemplar == nil || exemplar.GetLabel()[0].GetValue() != traceID.String() {
+			t.Errorf("Expected exemplar with trace ID %s, got %v", traceID, exemplar)
+		}
+		return
+	}
+	t.Error("Expected http_server_requests_total to be gathered")
+}
+
+// histogram returns the first histogram of the named family having label=value
+func TestHTTPServerMiddlewareHijack(t *testing.T) {
+	collector := NewMetricsCollector()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ws", func(w http.ResponseWriter, r *http.Request) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			t.Error("Expected the wrapped ResponseWriter to implement http.Hijacker")
+			return
+		}
+		conn, buf, err := hijacker.Hijack()
+		if err != nil {
+			t.Errorf("Failed to hijack: %v", err)
+			return
+		}
+		defer conn.Close()
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		_ = buf.Flush()
+	})
+	server := httptest.NewServer(HTTPServerMiddleware(collector, HTTPServerOptions{})(mux))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/ws")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("Expected status 101, got %d", resp.StatusCode)
+	}
+
+	requests := collector.CounterVec(httpServerRequestsOpts, []string{"method", "route", "status_class"})
+	if got := testutil.ToFloat64(requests.WithLabelValues("GET", "/ws", "1xx")); got != 1 {
+		t.Errorf("Expected the hijacked request to be recorded as 1xx, got %v", got)
+	}
+}
+
+func TestHTTPServerMiddlewareReadFrom(t *testing.T) {
+	collector := NewMetricsCollector()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /file", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(io.ReaderFrom); !ok {
+			t.Error("Expected the wrapped ResponseWriter to implement io.ReaderFrom")
+		}
+		_, _ = io.Copy(w, strings.NewReader("contents"))
+	})
+	handler := HTTPServerMiddleware(collector, HTTPServerOptions{})(mux)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/file", nil))
+	if recorder.Body.String() != "contents" {
+		t.Errorf("Expected body to be copied, got %q", recorder.Body.String())
+	}
+	if size := histogram(t, collector, "http_server_response_size_bytes", "route", "/file"); size.GetSampleSum() != 8 {
+		t.Errorf("Expected response size 8, got %v", size.GetSampleSum())
+	}
+}
+
+func histogram(t *testing.T, collector *MetricsCollector, name, label, value string) *dto.Histogram {
+	t.Helper()
+	families, err := collector.Registry().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if pair.GetName() == label && pair.GetValue() == value {
+					return metric.GetHistogram()
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"maps"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures a MetricsCollector
+type Option func(*options)
+
+type options struct {
+	namespace   string
+	subsystem   string
+	constLabels prometheus.Labels
+	builtins    *CollectorOptions
+}
+
+// WithNamespace prefixes the names of all metrics registered through the collector
+// with namespace, e.g. "checkout" turns orders_total into checkout_orders_total
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithSubsystem adds subsystem to the prefix of all metrics registered through
+// the collector, after the namespace
+func WithSubsystem(subsystem string) Option {
+	return func(o *options) {
+		o.subsystem = subsystem
+	}
+}
+
+// WithConstLabels adds labels such as env, region or team to all metrics
+// registered through the collector. Repeated calls are merged.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		if o.constLabels == nil {
+			o.constLabels = prometheus.Labels{}
+		}
+		maps.Copy(o.constLabels, labels)
+	}
+}
+
+// WithCollectorOptions selects the built-in collectors instead of DefaultCollectorOptions
+func WithCollectorOptions(builtins CollectorOptions) Option {
+	return func(o *options) {
+		o.builtins = &builtins
+	}
+}
+
+// registerer wraps registry so that metrics get the configured prefix and labels
+func (o options) registerer(registry *prometheus.Registry) prometheus.Registerer {
+	var registerer prometheus.Registerer = registry
+	if len(o.constLabels) > 0 {
+		registerer = prometheus.WrapRegistererWith(o.constLabels, registerer)
+	}
+	var prefix string
+	for _, part := range []string{o.namespace, o.subsystem} {
+		if part != "" {
+			prefix += part + "_"
+		}
+	}
+	if prefix != "" {
+		registerer = prometheus.WrapRegistererWithPrefix(prefix, registerer)
+	}
+	return registerer
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollectorOptions(t *testing.T) {
+	collector := NewMetricsCollector(
+		WithNamespace("checkout"),
+		WithSubsystem("api"),
+		WithConstLabels(prometheus.Labels{"env": "prod"}),
+		WithConstLabels(prometheus.Labels{"team": "payments"}),
+	)
+
+	counter := collector.Counter(prometheus.CounterOpts{Name: "orders_total", Help: "Orders"})
+	counter.Inc()
+	collector.CreateServiceInfoMetric("svc", "1.0.0", "i-1", nil, nil)
+
+	expected := `
+# HELP checkout_api_orders_total Orders
+# TYPE checkout_api_orders_total counter
+checkout_api_orders_total{env="prod",team="payments"} 1
+# HELP service_info Service information and build metadata
+# TYPE service_info gauge
+service_info{instance="i-1",service="svc",version="1.0.0"} 1
+`
+	if err := testutil.GatherAndCompare(collector.Registry(), strings.NewReader(expected),
+		"checkout_api_orders_total", "service_info"); err != nil {
+		t.Errorf("Unexpected metrics: %v", err)
+	}
+
+	// Built-in metrics keep their names
+	if count, err := testutil.GatherAndCount(collector.Registry(), "go_goroutines"); err != nil || count != 1 {
+		t.Errorf("Expected go_goroutines without namespace, got %d (%v)", count, err)
+	}
+
+	// Factory helpers return the existing metric despite the wrapping
+	if again := collector.Counter(prometheus.CounterOpts{Name: "orders_total", Help: "Orders"}); again != counter {
+		t.Error("Expected the existing counter to be returned")
+	}
+
+	if !collector.Unregister("orders_total") {
+		t.Error("Expected Unregister to find orders_total")
+	}
+	if !collector.Unregister("service_info") {
+		t.Error("Expected Unregister to find service_info")
+	}
+	if count, _ := testutil.GatherAndCount(collector.Registry(), "checkout_api_orders_total", "service_info"); count != 0 {
+		t.Errorf("Expected metrics to be unregistered, got %d", count)
+	}
+}
+
+func TestCollectorOptionsRegister(t *testing.T) {
+	collector := NewMetricsCollector(WithNamespace("checkout"))
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "workers", Help: "Workers"})
+	if err := collector.Register("workers", gauge); err != nil {
+		t.Fatalf("Failed to register gauge: %v", err)
+	}
+	if count, err := testutil.GatherAndCount(collector.Registry(), "checkout_workers"); err != nil || count != 1 {
+		t.Errorf("Expected checkout_workers, got %d (%v)", count, err)
+	}
+}
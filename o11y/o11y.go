@@ -0,0 +1,163 @@
+// Package o11y wires service metadata, logging, metrics, tracing and the operational server together.
+package o11y
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+	"github.com/corruptmane/corrupt-o11y-go/operational"
+	"github.com/corruptmane/corrupt-o11y-go/tracing"
+)
+
+// Handle owns every observability subsystem configured by Setup
+type Handle struct {
+	serviceInfo    metadata.ServiceInfo
+	status         *operational.Status
+	metrics        *metrics.MetricsCollector
+	tracerProvider *sdktrace.TracerProvider
+	server         *operational.OperationalServer
+	shutdown       *operational.ShutdownCoordinator
+}
+
+// Setup configures logging, metrics, tracing and the operational server from the
+// environment, applying any overrides given as options
+func Setup(ctx context.Context, opts ...Option) (*Handle, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &Handle{
+		status: operational.NewStatus(),
+	}
+
+	if o.serviceInfo != nil {
+		h.serviceInfo = *o.serviceInfo
+	} else {
+		h.serviceInfo = metadata.FromEnv()
+	}
+
+	h.metrics = o.metrics
+	if h.metrics == nil {
+		builtins, err := metrics.CollectorOptionsFromEnv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load metrics config: %w", err)
+		}
+		h.metrics = metrics.NewMetricsCollector(metrics.WithCollectorOptions(builtins))
+	}
+
+	loggingConfig := logging.FromEnv()
+	if o.logging != nil {
+		loggingConfig = *o.logging
+	}
+	loggingConfig.OTLP.ServiceName = h.serviceInfo.Name
+	loggingConfig.OTLP.ServiceVersion = h.serviceInfo.Version
+	if loggingConfig.Metrics == nil {
+		loggingConfig.Metrics = h.metrics
+	}
+	if err := logging.ConfigureLogging(loggingConfig); err != nil {
+		return nil, fmt.Errorf("failed to configure logging: %w", err)
+	}
+
+	h.metrics.CreateServiceInfoMetricFromServiceInfo(h.serviceInfo)
+
+	if !o.disableTracing {
+		var tracingConfig tracing.TracingConfig
+		if o.tracing != nil {
+			tracingConfig = *o.tracing
+		} else {
+			var err error
+			if tracingConfig, err = tracing.FromEnv(); err != nil {
+				return nil, errors.Join(fmt.Errorf("failed to load tracing config: %w", err), h.abort(ctx))
+			}
+		}
+
+		tracerProvider, err := tracing.ConfigureTracing(ctx, tracingConfig, h.serviceInfo.Name, h.serviceInfo.Version)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to configure tracing: %w", err), h.abort(ctx))
+		}
+		h.tracerProvider = tracerProvider
+	}
+
+	if !o.disableOperational {
+		operationalConfig := operational.FromEnv()
+		if o.operational != nil {
+			operationalConfig = *o.operational
+		}
+
+		server := operational.NewOperationalServer(operationalConfig, h.serviceInfo, h.status, h.metrics)
+		if err := server.Start(ctx); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to start operational server: %w", err), h.abort(ctx))
+		}
+		h.server = server
+	}
+
+	shutdownConfig := operational.ShutdownConfigFromEnv()
+	if o.shutdown != nil {
+		shutdownConfig = *o.shutdown
+	}
+	h.shutdown = operational.NewShutdownCoordinator(shutdownConfig, h.status, h.server, h.tracerProvider)
+
+	return h, nil
+}
+
+// abort releases what a failed Setup has already created
+func (h *Handle) abort(ctx context.Context) error {
+	var errs []error
+	if h.tracerProvider != nil {
+		errs = append(errs, h.tracerProvider.Shutdown(ctx))
+	}
+	errs = append(errs, logging.Shutdown(ctx))
+	return errors.Join(errs...)
+}
+
+// AddShutdownHook registers a hook that runs after readiness has been drained and
+// before the operational server and tracer provider are stopped
+func (h *Handle) AddShutdownHook(name string, hook operational.ShutdownHook, opts operational.HookOptions) {
+	h.shutdown.AddHook(name, hook, opts)
+}
+
+// WaitForShutdown blocks until SIGTERM or SIGINT is received or ctx is done and
+// then shuts everything down. A non-nil error means at least one step failed and
+// the process should exit with a non-zero status.
+func (h *Handle) WaitForShutdown(ctx context.Context) error {
+	return h.shutdown.Wait(ctx)
+}
+
+// Shutdown marks the service as not ready, waits for the drain period, runs the
+// registered shutdown hooks, stops the operational server, flushes and shuts down
+// the tracer provider together with its exporters and finally flushes log sinks
+func (h *Handle) Shutdown(ctx context.Context) error {
+	return h.shutdown.Shutdown(ctx)
+}
+
+// ServiceInfo returns the service metadata used to label all subsystems
+func (h *Handle) ServiceInfo() metadata.ServiceInfo {
+	return h.serviceInfo
+}
+
+// Status returns the readiness and liveness status served by the operational server
+func (h *Handle) Status() *operational.Status {
+	return h.status
+}
+
+// Metrics returns the metrics collector exposed on /metrics
+func (h *Handle) Metrics() *metrics.MetricsCollector {
+	return h.metrics
+}
+
+// TracerProvider returns the configured tracer provider, or nil if tracing is disabled
+func (h *Handle) TracerProvider() *sdktrace.TracerProvider {
+	return h.tracerProvider
+}
+
+// Server returns the operational server, or nil if it is disabled
+func (h *Handle) Server() *operational.OperationalServer {
+	return h.server
+}
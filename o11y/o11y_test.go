@@ -0,0 +1,125 @@
+package o11y
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+	"github.com/corruptmane/corrupt-o11y-go/operational"
+	"github.com/corruptmane/corrupt-o11y-go/tracing"
+)
+
+func testOptions() []Option {
+	return []Option{
+		WithServiceInfo(metadata.ServiceInfo{
+			Name:       "test-service",
+			Version:    "1.0.0",
+			InstanceID: "test-instance",
+			CommitSHA:  "abc123",
+			BuildTime:  "2023-01-01T00:00:00Z",
+		}),
+		WithLoggingConfig(logging.LoggingConfig{Level: slog.LevelInfo}),
+		WithTracingConfig(tracing.TracingConfig{ExportType: tracing.ExportTypeStdout}),
+		WithOperationalConfig(operational.OperationalServerConfig{Host: "127.0.0.1", Port: 0}),
+		WithShutdownConfig(operational.ShutdownConfig{HookTimeout: time.Second}),
+	}
+}
+
+func TestSetup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	h, err := Setup(ctx, testOptions()...)
+	if err != nil {
+		t.Fatalf("Failed to set up: %v", err)
+	}
+	defer h.Shutdown(ctx)
+
+	if h.ServiceInfo().Name != "test-service" {
+		t.Errorf("Expected service name to be 'test-service', got %s", h.ServiceInfo().Name)
+	}
+	if h.TracerProvider() == nil {
+		t.Error("Expected tracer provider to be configured")
+	}
+	if h.Server() == nil {
+		t.Fatal("Expected operational server to be started")
+	}
+
+	h.Status().SetReady(true)
+
+	resp, err := http.Get(h.Server().ServerURL() + "/ready")
+	if err != nil {
+		t.Fatalf("Failed to get ready endpoint: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected ready endpoint to return 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestSetupWithExistingCollector(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+
+	opts := append(testOptions(), WithMetricsCollector(collector), WithoutTracing(), WithoutOperationalServer())
+	h, err := Setup(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("Failed to set up: %v", err)
+	}
+
+	if h.Metrics() != collector {
+		t.Error("Expected Setup to use the supplied metrics collector")
+	}
+	if h.TracerProvider() != nil {
+		t.Error("Expected tracer provider to be nil when tracing is disabled")
+	}
+	if h.Server() != nil {
+		t.Error("Expected operational server to be nil when disabled")
+	}
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no error shutting down, got %v", err)
+	}
+}
+
+func TestSetupWithInvalidTracingConfig(t *testing.T) {
+	opts := append(testOptions(), WithTracingConfig(tracing.TracingConfig{ExportType: tracing.ExportTypeHTTP}))
+
+	if _, err := Setup(context.Background(), opts...); err == nil {
+		t.Error("Expected error when HTTP exporter has no endpoint")
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	h, err := Setup(ctx, testOptions()...)
+	if err != nil {
+		t.Fatalf("Failed to set up: %v", err)
+	}
+	h.Status().SetReady(true)
+
+	hookRan := false
+	h.AddShutdownHook("test", func(ctx context.Context) error {
+		hookRan = true
+		return nil
+	}, operational.HookOptions{})
+
+	if err := h.Shutdown(ctx); err != nil {
+		t.Errorf("Expected no error shutting down, got %v", err)
+	}
+	if h.Status().IsReady() {
+		t.Error("Expected status to not be ready after shutdown")
+	}
+	if !hookRan {
+		t.Error("Expected shutdown hook to run")
+	}
+
+	if _, err := http.Get(h.Server().ServerURL() + "/health"); err == nil {
+		t.Error("Expected operational server to be stopped after shutdown")
+	}
+}
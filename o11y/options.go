@@ -0,0 +1,79 @@
+package o11y
+
+import (
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+	"github.com/corruptmane/corrupt-o11y-go/operational"
+	"github.com/corruptmane/corrupt-o11y-go/tracing"
+)
+
+// Option overrides part of the configuration Setup would otherwise read from the environment
+type Option func(*options)
+
+type options struct {
+	serviceInfo        *metadata.ServiceInfo
+	logging            *logging.LoggingConfig
+	tracing            *tracing.TracingConfig
+	operational        *operational.OperationalServerConfig
+	shutdown           *operational.ShutdownConfig
+	metrics            *metrics.MetricsCollector
+	disableTracing     bool
+	disableOperational bool
+}
+
+// WithServiceInfo uses the given service metadata instead of metadata.FromEnv
+func WithServiceInfo(serviceInfo metadata.ServiceInfo) Option {
+	return func(o *options) {
+		o.serviceInfo = &serviceInfo
+	}
+}
+
+// WithLoggingConfig uses the given logging configuration instead of logging.FromEnv
+func WithLoggingConfig(config logging.LoggingConfig) Option {
+	return func(o *options) {
+		o.logging = &config
+	}
+}
+
+// WithTracingConfig uses the given tracing configuration instead of tracing.FromEnv
+func WithTracingConfig(config tracing.TracingConfig) Option {
+	return func(o *options) {
+		o.tracing = &config
+	}
+}
+
+// WithOperationalConfig uses the given operational server configuration instead of operational.FromEnv
+func WithOperationalConfig(config operational.OperationalServerConfig) Option {
+	return func(o *options) {
+		o.operational = &config
+	}
+}
+
+// WithShutdownConfig uses the given shutdown configuration instead of operational.ShutdownConfigFromEnv
+func WithShutdownConfig(config operational.ShutdownConfig) Option {
+	return func(o *options) {
+		o.shutdown = &config
+	}
+}
+
+// WithMetricsCollector uses an existing metrics collector instead of creating a new one
+func WithMetricsCollector(collector *metrics.MetricsCollector) Option {
+	return func(o *options) {
+		o.metrics = collector
+	}
+}
+
+// WithoutTracing skips tracer provider setup entirely
+func WithoutTracing() Option {
+	return func(o *options) {
+		o.disableTracing = true
+	}
+}
+
+// WithoutOperationalServer skips starting the operational HTTP server
+func WithoutOperationalServer() Option {
+	return func(o *options) {
+		o.disableOperational = true
+	}
+}
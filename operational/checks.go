@@ -0,0 +1,182 @@
+package operational
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCheckTimeout bounds a health check that does not set its own timeout
+	defaultCheckTimeout = 5 * time.Second
+)
+
+// CheckFunc reports a non-nil error when the checked dependency is unhealthy
+type CheckFunc func(ctx context.Context) error
+
+// CheckOptions controls how a health check is run
+type CheckOptions struct {
+	// Timeout bounds a single run of the check; zero uses a 5s default
+	Timeout time.Duration
+	// CacheTTL reuses the last result for this long; zero runs the check on every probe
+	CacheTTL time.Duration
+}
+
+// CheckResult is the outcome of a single health check run
+type CheckResult struct {
+	Name        string
+	Err         error
+	Latency     time.Duration
+	LastSuccess time.Time
+}
+
+// Healthy returns whether the check passed
+func (r CheckResult) Healthy() bool {
+	return r.Err == nil
+}
+
+// CheckReport aggregates the manual status flag with the results of all checks
+type CheckReport struct {
+	Healthy bool
+	Checks  []CheckResult
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+	opts CheckOptions
+
+	mu          sync.Mutex
+	last        CheckResult
+	lastRun     time.Time
+	lastSuccess time.Time
+}
+
+func (c *check) run(ctx context.Context) CheckResult {
+	c.mu.Lock()
+	if c.opts.CacheTTL > 0 && !c.lastRun.IsZero() && time.Since(c.lastRun) < c.opts.CacheTTL {
+		result := c.last
+		c.mu.Unlock()
+		return result
+	}
+	c.mu.Unlock()
+
+	timeout := c.opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	latency := time.Since(start)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.lastSuccess = start
+	}
+	c.last = CheckResult{
+		Name:        c.name,
+		Err:         err,
+		Latency:     latency,
+		LastSuccess: c.lastSuccess,
+	}
+	c.lastRun = start
+	return c.last
+}
+
+// checkRegistry holds named checks in registration order
+type checkRegistry struct {
+	mu     sync.RWMutex
+	checks []*check
+}
+
+// add registers a check, replacing any existing check with the same name
+func (r *checkRegistry) add(name string, fn CheckFunc, opts CheckOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &check{name: name, fn: fn, opts: opts}
+	for i, existing := range r.checks {
+		if existing.name == name {
+			r.checks[i] = c
+			return
+		}
+	}
+	r.checks = append(r.checks, c)
+}
+
+// get returns the check with the given name
+func (r *checkRegistry) get(name string) (*check, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.checks {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// names returns the names of all checks in registration order
+func (r *checkRegistry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checks))
+	for _, c := range r.checks {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// run executes all checks not named in exclude concurrently and returns their
+// results in registration order
+func (r *checkRegistry) run(ctx context.Context, exclude ...string) []CheckResult {
+	r.mu.RLock()
+	checks := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		if !slices.Contains(exclude, c.name) {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func newCheckReport(flag bool, results []CheckResult) CheckReport {
+	healthy := flag
+	for _, result := range results {
+		if !result.Healthy() {
+			healthy = false
+		}
+	}
+	return CheckReport{Healthy: healthy, Checks: results}
+}
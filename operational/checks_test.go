@@ -0,0 +1,86 @@
+package operational
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckTimeout(t *testing.T) {
+	var registry checkRegistry
+	registry.add("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}, CheckOptions{Timeout: 10 * time.Millisecond})
+
+	results := registry.run(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if !errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("Expected slow check to time out, got %v", results[0].Err)
+	}
+	if results[0].Latency >= time.Second {
+		t.Errorf("Expected timed out check to return early, took %s", results[0].Latency)
+	}
+}
+
+func TestCheckCache(t *testing.T) {
+	var calls atomic.Int32
+	var registry checkRegistry
+	registry.add("cached", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, CheckOptions{CacheTTL: time.Minute})
+
+	registry.run(context.Background())
+	results := registry.run(context.Background())
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected cached check to run once, ran %d times", calls.Load())
+	}
+	if !results[0].Healthy() {
+		t.Error("Expected cached result to be healthy")
+	}
+}
+
+func TestCheckLastSuccess(t *testing.T) {
+	fail := false
+	var registry checkRegistry
+	registry.add("flaky", func(ctx context.Context) error {
+		if fail {
+			return errors.New("down")
+		}
+		return nil
+	}, CheckOptions{})
+
+	first := registry.run(context.Background())[0]
+	if first.LastSuccess.IsZero() {
+		t.Error("Expected LastSuccess to be set after a passing run")
+	}
+
+	fail = true
+	second := registry.run(context.Background())[0]
+	if second.Healthy() {
+		t.Error("Expected failing check to be unhealthy")
+	}
+	if !second.LastSuccess.Equal(first.LastSuccess) {
+		t.Errorf("Expected LastSuccess to be kept from the previous run, got %v", second.LastSuccess)
+	}
+}
+
+func TestCheckReplace(t *testing.T) {
+	var registry checkRegistry
+	registry.add("db", func(ctx context.Context) error { return errors.New("down") }, CheckOptions{})
+	registry.add("db", func(ctx context.Context) error { return nil }, CheckOptions{})
+
+	results := registry.run(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("Expected re-registered check to replace the original, got %d results", len(results))
+	}
+	if !results[0].Healthy() {
+		t.Error("Expected replacement check to be used")
+	}
+}
@@ -4,6 +4,7 @@ package operational
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // OperationalServerConfig holds configuration for operational HTTP server
@@ -27,6 +28,30 @@ func FromEnv() OperationalServerConfig {
 	}
 }
 
+// ShutdownConfig holds configuration for graceful shutdown
+type ShutdownConfig struct {
+	DrainPeriod time.Duration
+	HookTimeout time.Duration
+}
+
+// ShutdownConfigFromEnv creates ShutdownConfig from environment variables
+func ShutdownConfigFromEnv() ShutdownConfig {
+	return ShutdownConfig{
+		DrainPeriod: parseDurationOrDefault(os.Getenv("SHUTDOWN_DRAIN_PERIOD"), 5*time.Second),
+		HookTimeout: parseDurationOrDefault(os.Getenv("SHUTDOWN_HOOK_TIMEOUT"), 10*time.Second),
+	}
+}
+
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	if parsed, err := time.ParseDuration(value); err == nil && parsed >= 0 {
+		return parsed
+	}
+	return defaultValue
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
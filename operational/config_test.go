@@ -3,6 +3,7 @@ package operational
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestFromEnv(t *testing.T) {
@@ -50,3 +51,35 @@ func TestFromEnvWithInvalidPort(t *testing.T) {
 		t.Errorf("Expected Port to be 42069 (default) for invalid port, got %d", config.Port)
 	}
 }
+
+func TestShutdownConfigFromEnv(t *testing.T) {
+	// Test with default values
+	config := ShutdownConfigFromEnv()
+
+	if config.DrainPeriod != 5*time.Second {
+		t.Errorf("Expected DrainPeriod to be 5s, got %s", config.DrainPeriod)
+	}
+	if config.HookTimeout != 10*time.Second {
+		t.Errorf("Expected HookTimeout to be 10s, got %s", config.HookTimeout)
+	}
+}
+
+func TestShutdownConfigFromEnvWithValues(t *testing.T) {
+	// Set environment variables
+	os.Setenv("SHUTDOWN_DRAIN_PERIOD", "15s")
+	os.Setenv("SHUTDOWN_HOOK_TIMEOUT", "invalid")
+	defer func() {
+		os.Unsetenv("SHUTDOWN_DRAIN_PERIOD")
+		os.Unsetenv("SHUTDOWN_HOOK_TIMEOUT")
+	}()
+
+	config := ShutdownConfigFromEnv()
+
+	if config.DrainPeriod != 15*time.Second {
+		t.Errorf("Expected DrainPeriod to be 15s, got %s", config.DrainPeriod)
+	}
+	// Should fall back to default
+	if config.HookTimeout != 10*time.Second {
+		t.Errorf("Expected HookTimeout to be 10s (default) for invalid value, got %s", config.HookTimeout)
+	}
+}
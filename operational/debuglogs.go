@@ -0,0 +1,133 @@
+package operational
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+)
+
+// sseKeepAlive is how often an idle log stream sends a comment to keep proxies from closing it
+const sseKeepAlive = 15 * time.Second
+
+type debugLogsResponse struct {
+	Records []logging.MemoryRecord `json:"records"`
+}
+
+// handleDebugLogs returns the records kept by logging.MemoryLogs as JSON, or
+// streams them as server-sent events with ?follow or Accept: text/event-stream.
+// Records can be filtered with ?level=, ?logger= and ?trace_id= and the number of
+// buffered records returned is bounded by ?limit=.
+func (s *OperationalServer) handleDebugLogs(w http.ResponseWriter, r *http.Request) {
+	buffer := logging.MemoryLogs()
+	if buffer == nil {
+		http.Error(w, "log buffer is not enabled", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := logging.MemoryFilter{
+		Logger:  query.Get("logger"),
+		TraceID: query.Get("trace_id"),
+	}
+	if value := query.Get("level"); value != "" {
+		level, err := logging.ParseLevel(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.Level = level
+	}
+
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			http.Error(w, fmt.Sprintf("invalid limit: %s", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if query.Has("follow") || r.Header.Get("Accept") == "text/event-stream" {
+		streamLogs(w, r, s.done, buffer, filter, limit)
+		return
+	}
+
+	data, err := json.Marshal(debugLogsResponse{Records: buffer.Records(filter, limit)})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode log records: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// streamLogs sends the buffered records and then every new record until the
+// client disconnects or done is closed
+func streamLogs(w http.ResponseWriter, r *http.Request, done <-chan struct{}, buffer *logging.MemoryBuffer, filter logging.MemoryFilter, limit int) {
+	controller := http.NewResponseController(w)
+	// The stream outlives the server's write timeout
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	records, unsubscribe := buffer.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	// Records added between Subscribe and Records are both buffered and
+	// received, so live records up to the last buffered one are skipped
+	var last time.Time
+	for _, record := range buffer.Records(filter, limit) {
+		if writeEvent(w, record) != nil {
+			return
+		}
+		last = record.Time
+	}
+	if controller.Flush() != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case record, ok := <-records:
+			if !ok {
+				return
+			}
+			if !filter.Match(record) || !record.Time.After(last) {
+				continue
+			}
+			if writeEvent(w, record) != nil {
+				return
+			}
+		}
+		if controller.Flush() != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, record logging.MemoryRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
@@ -0,0 +1,188 @@
+package operational
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+func startDebugLogsServer(t *testing.T) (*OperationalServer, context.Context) {
+	t.Helper()
+	server := NewOperationalServer(OperationalServerConfig{Host: "127.0.0.1", Port: 0}, metadata.ServiceInfo{}, NewStatus(), metrics.NewMetricsCollector())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	t.Cleanup(func() { server.Stop(context.Background()) })
+	return server, ctx
+}
+
+func TestOperationalServerDebugLogs(t *testing.T) {
+	defer logging.ConfigureLogging(logging.LoggingConfig{Level: slog.LevelInfo})
+	if err := logging.ConfigureLogging(logging.LoggingConfig{
+		Level:  slog.LevelInfo,
+		Output: logging.OutputStderr,
+		Memory: logging.MemoryConfig{Enabled: true},
+	}); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	server, _ := startDebugLogsServer(t)
+
+	logging.GetLogger("db").Warn("slow query")
+	logging.GetLogger("http").Info("request")
+	logging.GetLogger("http").Error("request failed")
+
+	get := func(query string) debugLogsResponse {
+		t.Helper()
+		resp, err := http.Get(server.ServerURL() + "/debug/logs" + query)
+		if err != nil {
+			t.Fatalf("Failed to get logs: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+		var body debugLogsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return body
+	}
+
+	if got := len(get("").Records); got != 3 {
+		t.Errorf("Expected 3 records, got %d", got)
+	}
+	if got := len(get("?logger=http").Records); got != 2 {
+		t.Errorf("Expected 2 http records, got %d", got)
+	}
+	if got := get("?level=warn&limit=1").Records; len(got) != 1 || got[0].Message != "request failed" {
+		t.Errorf("Expected the newest WARN+ record, got %v", got)
+	}
+
+	resp, err := http.Get(server.ServerURL() + "/debug/logs?level=loud")
+	if err != nil {
+		t.Fatalf("Failed to get logs: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid level, got %d", resp.StatusCode)
+	}
+}
+
+func TestOperationalServerDebugLogsStream(t *testing.T) {
+	defer logging.ConfigureLogging(logging.LoggingConfig{Level: slog.LevelInfo})
+	if err := logging.ConfigureLogging(logging.LoggingConfig{
+		Level:  slog.LevelInfo,
+		Output: logging.OutputStderr,
+		Memory: logging.MemoryConfig{Enabled: true},
+	}); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	server, ctx := startDebugLogsServer(t)
+	logging.GetLogger("app").Info("buffered")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.ServerURL()+"/debug/logs?follow&logger=app", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to stream logs: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected text/event-stream, got %s", ct)
+	}
+
+	events := bufio.NewScanner(resp.Body)
+	next := func() logging.MemoryRecord {
+		t.Helper()
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				var record logging.MemoryRecord
+				if err := json.Unmarshal([]byte(data), &record); err != nil {
+					t.Fatalf("Failed to decode event: %v", err)
+				}
+				return record
+			}
+		}
+		t.Fatalf("Stream ended: %v", events.Err())
+		return logging.MemoryRecord{}
+	}
+
+	if record := next(); record.Message != "buffered" {
+		t.Errorf("Expected buffered record first, got %v", record)
+	}
+
+	logging.GetLogger("other").Info("filtered out")
+	logging.GetLogger("app").Info("live")
+	if record := next(); record.Message != "live" {
+		t.Errorf("Expected live record, got %v", record)
+	}
+}
+
+func TestOperationalServerDebugLogsStreamEndsOnStop(t *testing.T) {
+	defer logging.ConfigureLogging(logging.LoggingConfig{Level: slog.LevelInfo})
+	if err := logging.ConfigureLogging(logging.LoggingConfig{
+		Level:  slog.LevelInfo,
+		Output: logging.OutputStderr,
+		Memory: logging.MemoryConfig{Enabled: true},
+	}); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	server, ctx := startDebugLogsServer(t)
+	logging.GetLogger("app").Info("buffered")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.ServerURL()+"/debug/logs?follow", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to stream logs: %v", err)
+	}
+	defer resp.Body.Close()
+
+	events := bufio.NewScanner(resp.Body)
+	if !events.Scan() || !strings.HasPrefix(events.Text(), "data: ") {
+		t.Fatalf("Expected buffered record, got %q", events.Text())
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Stop(stopCtx); err != nil {
+		t.Fatalf("Expected stop to end the stream, got %v", err)
+	}
+	// The remaining events are read until the server closes the connection
+	for events.Scan() {
+		continue
+	}
+}
+
+func TestOperationalServerDebugLogsDisabled(t *testing.T) {
+	defer logging.ConfigureLogging(logging.LoggingConfig{Level: slog.LevelInfo})
+	if err := logging.ConfigureLogging(logging.LoggingConfig{Level: slog.LevelInfo}); err != nil {
+		t.Fatalf("Failed to configure logging: %v", err)
+	}
+
+	server, _ := startDebugLogsServer(t)
+
+	resp, err := http.Get(server.ServerURL() + "/debug/logs")
+	if err != nil {
+		t.Fatalf("Failed to get logs: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,97 @@
+package operational
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+type logLevelResponse struct {
+	Level    string     `json:"level"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+	TTL   string `json:"ttl,omitempty"`
+}
+
+// newLogLevelChangesMetric registers the log level change counter, shared by
+// servers using the same collector. If the application already registered a
+// conflicting log_level_changes_total, changes are counted in an unregistered
+// counter instead.
+func newLogLevelChangesMetric(collector *metrics.MetricsCollector) *prometheus.CounterVec {
+	opts := prometheus.CounterOpts{
+		Name: "log_level_changes_total",
+		Help: "Number of runtime log level changes made through the operational server",
+	}
+	counter, err := collector.RegisterCounterVec(opts, []string{"level"})
+	if err != nil {
+		return prometheus.NewCounterVec(opts, []string{"level"})
+	}
+	return counter
+}
+
+func (s *OperationalServer) handleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeLogLevel(w)
+}
+
+func (s *OperationalServer) handlePutLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			http.Error(w, fmt.Sprintf("invalid ttl: %s", req.TTL), http.StatusBadRequest)
+			return
+		}
+	}
+
+	previous := logging.GetLevel()
+	if ttl > 0 {
+		logging.SetLevelFor(level, ttl)
+	} else {
+		logging.SetLevel(level)
+	}
+	s.logLevelChanges.WithLabelValues(level.String()).Inc()
+
+	logger := logging.GetLogger("operational")
+	logger.Warn("log level changed",
+		slog.String("previous", previous.String()),
+		slog.String("level", level.String()),
+		slog.Duration("ttl", ttl),
+		slog.String("remote_addr", r.RemoteAddr),
+		slog.String("user_agent", r.UserAgent()),
+	)
+
+	writeLogLevel(w)
+}
+
+func writeLogLevel(w http.ResponseWriter) {
+	resp := logLevelResponse{Level: logging.GetLevel().String()}
+	if revertAt := logging.LevelRevertAt(); !revertAt.IsZero() {
+		revertAt = revertAt.UTC()
+		resp.RevertAt = &revertAt
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,121 @@
+package operational
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+	"github.com/corruptmane/corrupt-o11y-go/logging/logtest"
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+func TestOperationalServerLogLevel(t *testing.T) {
+	defer logging.SetLevel(slog.LevelInfo)
+	logging.SetLevel(slog.LevelInfo)
+	logs := logtest.Capture(t)
+
+	config := OperationalServerConfig{
+		Host: "127.0.0.1",
+		Port: 0,
+	}
+
+	server := NewOperationalServer(config, metadata.ServiceInfo{}, NewStatus(), metrics.NewMetricsCollector())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop(ctx)
+
+	put := func(body string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPut, server.ServerURL()+"/loglevel", strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to put loglevel: %v", err)
+		}
+		return resp
+	}
+
+	resp := put(`{"level": "debug", "ttl": "10m"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected loglevel PUT to return 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Level    string     `json:"level"`
+		RevertAt *time.Time `json:"revert_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode loglevel response: %v", err)
+	}
+	if body.Level != "DEBUG" || body.RevertAt == nil {
+		t.Errorf("Expected DEBUG with a revert time, got %+v", body)
+	}
+	if logging.GetLevel() != slog.LevelDebug {
+		t.Errorf("Expected global level to be DEBUG, got %v", logging.GetLevel())
+	}
+
+	if got := testutil.ToFloat64(server.logLevelChanges.WithLabelValues("DEBUG")); got != 1 {
+		t.Errorf("Expected 1 recorded DEBUG change, got %v", got)
+	}
+	logs.AssertLogged(t,
+		logtest.Level(slog.LevelWarn),
+		logtest.Message("log level changed"),
+		logtest.Attr("previous", "INFO"),
+		logtest.Attr("level", "DEBUG"),
+		logtest.Attr("ttl", 10*time.Minute),
+	)
+
+	resp = put(`{"level": "loud"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected invalid level to return 400, got %d", resp.StatusCode)
+	}
+
+	resp, err := http.Get(server.ServerURL() + "/loglevel")
+	if err != nil {
+		t.Fatalf("Failed to get loglevel: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode loglevel response: %v", err)
+	}
+	if body.Level != "DEBUG" {
+		t.Errorf("Expected GET to report DEBUG, got %s", body.Level)
+	}
+}
+
+func TestLogLevelChangesMetricSharedCollector(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+
+	first := newLogLevelChangesMetric(collector)
+	second := newLogLevelChangesMetric(collector)
+
+	if first != second {
+		t.Error("Expected servers sharing a collector to share the log level change counter")
+	}
+}
+
+func TestLogLevelChangesMetricConflict(t *testing.T) {
+	collector := metrics.NewMetricsCollector()
+	collector.Counter(prometheus.CounterOpts{Name: "log_level_changes_total", Help: "Application counter"})
+
+	counter := NewOperationalServer(OperationalServerConfig{}, metadata.ServiceInfo{}, NewStatus(), collector).logLevelChanges
+	if counter == nil {
+		t.Fatal("Expected a local counter for a conflicting metric")
+	}
+	counter.WithLabelValues("DEBUG").Inc()
+}
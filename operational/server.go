@@ -7,8 +7,12 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/corruptmane/corrupt-o11y-go/logging"
@@ -24,6 +28,10 @@ type OperationalServer struct {
 	serviceInfo metadata.ServiceInfo
 	server      *http.Server
 	serverURL   string
+	// done is closed when the server shuts down to end long-lived responses such as log streams
+	done chan struct{}
+
+	logLevelChanges *prometheus.CounterVec
 }
 
 // NewOperationalServer creates a new operational server
@@ -34,10 +42,11 @@ func NewOperationalServer(
 	metricsCollector *metrics.MetricsCollector,
 ) *OperationalServer {
 	return &OperationalServer{
-		config:      config,
-		status:      status,
-		metrics:     metricsCollector,
-		serviceInfo: serviceInfo,
+		config:          config,
+		status:          status,
+		metrics:         metricsCollector,
+		serviceInfo:     serviceInfo,
+		logLevelChanges: newLogLevelChangesMetric(metricsCollector),
 	}
 }
 
@@ -48,7 +57,14 @@ func (s *OperationalServer) Start(ctx context.Context) error {
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/ready", s.handleReady)
 	mux.HandleFunc("/info", s.handleInfo)
-	mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.Registry(), promhttp.HandlerOpts{}))
+	for _, p := range s.probes() {
+		mux.HandleFunc("/"+p.name, s.handleProbe(p))
+		mux.HandleFunc("/"+p.name+"/{check}", s.handleProbeCheck(p))
+	}
+	mux.HandleFunc("GET /loglevel", s.handleGetLogLevel)
+	mux.HandleFunc("PUT /loglevel", s.handlePutLogLevel)
+	mux.HandleFunc("GET /debug/logs", s.handleDebugLogs)
+	mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.Registry(), promhttp.HandlerOpts{EnableOpenMetrics: true}))
 
 	// Create listener to get actual port
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
@@ -62,6 +78,12 @@ func (s *OperationalServer) Start(ctx context.Context) error {
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
+	// Shutdown does not interrupt active connections, so streams have to end themselves
+	s.done = make(chan struct{})
+	var closeDone sync.Once
+	s.server.RegisterOnShutdown(func() {
+		closeDone.Do(func() { close(s.done) })
+	})
 
 	// Set server URL using actual assigned port
 	host := s.config.Host
@@ -95,19 +117,11 @@ func (s *OperationalServer) ServerURL() string {
 }
 
 func (s *OperationalServer) handleHealth(w http.ResponseWriter, r *http.Request) {
-	if s.status.IsAlive() {
-		w.WriteHeader(http.StatusOK)
-	} else {
-		w.WriteHeader(http.StatusServiceUnavailable)
-	}
+	writeCheckReport(w, r, s.status.Liveness(r.Context()))
 }
 
 func (s *OperationalServer) handleReady(w http.ResponseWriter, r *http.Request) {
-	if s.status.IsReady() {
-		w.WriteHeader(http.StatusOK)
-	} else {
-		w.WriteHeader(http.StatusServiceUnavailable)
-	}
+	writeCheckReport(w, r, s.status.Readiness(r.Context()))
 }
 
 func (s *OperationalServer) handleInfo(w http.ResponseWriter, r *http.Request) {
@@ -115,3 +129,158 @@ func (s *OperationalServer) handleInfo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(s.serviceInfo.AsMap())
 }
+
+type checkResultJSON struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	LatencyMS   float64    `json:"latency_ms"`
+	Error       string     `json:"error,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+}
+
+type checkReportJSON struct {
+	Status string            `json:"status"`
+	Checks []checkResultJSON `json:"checks"`
+}
+
+// writeCheckReport writes the probe status code, and the per-check results as JSON when ?verbose is set
+func writeCheckReport(w http.ResponseWriter, r *http.Request, report CheckReport) {
+	code := http.StatusOK
+	if !report.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	if !r.URL.Query().Has("verbose") {
+		w.WriteHeader(code)
+		return
+	}
+
+	body := checkReportJSON{
+		Status: checkStatus(report.Healthy),
+		Checks: make([]checkResultJSON, 0, len(report.Checks)),
+	}
+	for _, result := range report.Checks {
+		item := checkResultJSON{
+			Name:      result.Name,
+			Status:    checkStatus(result.Healthy()),
+			LatencyMS: float64(result.Latency.Microseconds()) / 1000,
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		}
+		if !result.LastSuccess.IsZero() {
+			lastSuccess := result.LastSuccess.UTC()
+			item.LastSuccess = &lastSuccess
+		}
+		body.Checks = append(body.Checks, item)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func checkStatus(healthy bool) string {
+	if healthy {
+		return "ok"
+	}
+	return "failed"
+}
+
+// probe describes one of the Kubernetes-style /livez, /readyz and /startupz endpoints
+type probe struct {
+	name     string
+	flagName string
+	flag     func() bool
+	checks   *checkRegistry
+}
+
+func (s *OperationalServer) probes() []probe {
+	return []probe{
+		{name: "livez", flagName: "alive", flag: s.status.IsAlive, checks: &s.status.livenessChecks},
+		{name: "readyz", flagName: "ready", flag: s.status.IsReady, checks: &s.status.readinessChecks},
+		{name: "startupz", flagName: "started", flag: s.status.IsStarted, checks: &s.status.startupChecks},
+	}
+}
+
+// handleProbe serves a probe in the Kubernetes apiserver format: "ok" on success,
+// and a "[+]name ok" / "[-]name failed" listing when verbose or failing
+func (s *OperationalServer) handleProbe(p probe) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		exclude := parseExclude(query["exclude"])
+		flag := p.flag()
+		report := newCheckReport(flag, p.checks.run(r.Context(), exclude...))
+
+		if report.Healthy && !query.Has("verbose") {
+			writeProbeText(w, http.StatusOK, "ok")
+			return
+		}
+
+		var b strings.Builder
+		if flag {
+			fmt.Fprintf(&b, "[+]%s ok\n", p.flagName)
+		} else {
+			fmt.Fprintf(&b, "[-]%s failed: not marked %s\n", p.flagName, p.flagName)
+		}
+		for _, result := range report.Checks {
+			if result.Healthy() {
+				fmt.Fprintf(&b, "[+]%s ok\n", result.Name)
+			} else {
+				fmt.Fprintf(&b, "[-]%s failed: reason withheld\n", result.Name)
+			}
+		}
+		for _, name := range p.checks.names() {
+			if slices.Contains(exclude, name) {
+				fmt.Fprintf(&b, "[+]%s excluded: ok\n", name)
+			}
+		}
+
+		code := http.StatusOK
+		if report.Healthy {
+			fmt.Fprintf(&b, "%s check passed", p.name)
+		} else {
+			code = http.StatusServiceUnavailable
+			fmt.Fprintf(&b, "%s check failed", p.name)
+		}
+		writeProbeText(w, code, b.String())
+	}
+}
+
+// handleProbeCheck serves a single named check of a probe
+func (s *OperationalServer) handleProbeCheck(p probe) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("check")
+		c, ok := p.checks.get(name)
+		if !ok {
+			writeProbeText(w, http.StatusNotFound, fmt.Sprintf("%s check %q not found", p.name, name))
+			return
+		}
+
+		if result := c.run(r.Context()); !result.Healthy() {
+			writeProbeText(w, http.StatusServiceUnavailable, fmt.Sprintf("[-]%s failed: reason withheld", name))
+			return
+		}
+		writeProbeText(w, http.StatusOK, "ok")
+	}
+}
+
+func writeProbeText(w http.ResponseWriter, code int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintln(w, body)
+}
+
+// parseExclude accepts both repeated and comma-separated exclude parameters
+func parseExclude(values []string) []string {
+	var exclude []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				exclude = append(exclude, name)
+			}
+		}
+	}
+	return exclude
+}
@@ -3,6 +3,8 @@ package operational
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -182,3 +184,125 @@ func TestOperationalServerStop(t *testing.T) {
 		t.Errorf("Failed to stop server second time: %v", err)
 	}
 }
+
+func TestOperationalServerVerboseChecks(t *testing.T) {
+	config := OperationalServerConfig{
+		Host: "127.0.0.1",
+		Port: 0,
+	}
+
+	status := NewStatus()
+	status.SetReady(true)
+	status.AddReadinessCheck("postgres", func(ctx context.Context) error { return nil }, CheckOptions{})
+	status.AddReadinessCheck("redis", func(ctx context.Context) error { return errors.New("connection refused") }, CheckOptions{})
+
+	server := NewOperationalServer(config, metadata.ServiceInfo{}, status, metrics.NewMetricsCollector())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop(ctx)
+
+	resp, err := http.Get(server.ServerURL() + "/ready?verbose")
+	if err != nil {
+		t.Fatalf("Failed to get ready endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected ready endpoint to return 503 with a failing check, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+		Checks []struct {
+			Name        string  `json:"name"`
+			Status      string  `json:"status"`
+			Error       string  `json:"error"`
+			LastSuccess *string `json:"last_success"`
+		} `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode verbose response: %v", err)
+	}
+
+	if body.Status != "failed" {
+		t.Errorf("Expected overall status to be 'failed', got %s", body.Status)
+	}
+	if len(body.Checks) != 2 {
+		t.Fatalf("Expected 2 checks, got %d", len(body.Checks))
+	}
+	if body.Checks[0].Name != "postgres" || body.Checks[0].Status != "ok" || body.Checks[0].LastSuccess == nil {
+		t.Errorf("Unexpected postgres check result: %+v", body.Checks[0])
+	}
+	if body.Checks[1].Name != "redis" || body.Checks[1].Status != "failed" || body.Checks[1].Error != "connection refused" {
+		t.Errorf("Unexpected redis check result: %+v", body.Checks[1])
+	}
+}
+
+func TestOperationalServerKubernetesProbes(t *testing.T) {
+	config := OperationalServerConfig{
+		Host: "127.0.0.1",
+		Port: 0,
+	}
+
+	status := NewStatus()
+	status.SetReady(true)
+	status.AddReadinessCheck("db", func(ctx context.Context) error { return nil }, CheckOptions{})
+	status.AddReadinessCheck("cache", func(ctx context.Context) error { return errors.New("evicted") }, CheckOptions{})
+
+	server := NewOperationalServer(config, metadata.ServiceInfo{}, status, metrics.NewMetricsCollector())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop(ctx)
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(server.ServerURL() + path)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	tests := []struct {
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{"/livez", http.StatusOK, "ok\n"},
+		{"/livez?verbose", http.StatusOK, "[+]alive ok\nlivez check passed\n"},
+		{"/readyz", http.StatusServiceUnavailable, "[+]ready ok\n[+]db ok\n[-]cache failed: reason withheld\nreadyz check failed\n"},
+		{"/readyz?exclude=cache", http.StatusOK, "ok\n"},
+		{"/readyz?exclude=cache&verbose", http.StatusOK, "[+]ready ok\n[+]db ok\n[+]cache excluded: ok\nreadyz check passed\n"},
+		{"/readyz/db", http.StatusOK, "ok\n"},
+		{"/readyz/cache", http.StatusServiceUnavailable, "[-]cache failed: reason withheld\n"},
+		{"/readyz/missing", http.StatusNotFound, "readyz check \"missing\" not found\n"},
+		{"/startupz", http.StatusServiceUnavailable, "[-]started failed: not marked started\nstartupz check failed\n"},
+	}
+
+	for _, test := range tests {
+		code, body := get(test.path)
+		if code != test.expectedCode {
+			t.Errorf("GET %s returned %d, expected %d", test.path, code, test.expectedCode)
+		}
+		if body != test.expectedBody {
+			t.Errorf("GET %s returned body %q, expected %q", test.path, body, test.expectedBody)
+		}
+	}
+
+	status.SetStarted(true)
+	if code, _ := get("/startupz"); code != http.StatusOK {
+		t.Errorf("Expected startupz to return 200 after SetStarted(true), got %d", code)
+	}
+}
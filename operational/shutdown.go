@@ -0,0 +1,171 @@
+package operational
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/corruptmane/corrupt-o11y-go/logging"
+)
+
+// ShutdownHook releases a resource during graceful shutdown
+type ShutdownHook func(ctx context.Context) error
+
+// HookOptions controls how a shutdown hook is run
+type HookOptions struct {
+	// Timeout bounds the hook; zero uses ShutdownConfig.HookTimeout
+	Timeout time.Duration
+	// Order sorts hooks ascending; hooks with equal order run in registration order
+	Order int
+}
+
+type shutdownHook struct {
+	name string
+	hook ShutdownHook
+	opts HookOptions
+}
+
+// ShutdownCoordinator drains traffic and runs shutdown hooks when the process is asked to stop
+type ShutdownCoordinator struct {
+	config         ShutdownConfig
+	status         *Status
+	server         *OperationalServer
+	tracerProvider *sdktrace.TracerProvider
+
+	mu    sync.Mutex
+	hooks []shutdownHook
+
+	once sync.Once
+	err  error
+}
+
+// NewShutdownCoordinator creates a new shutdown coordinator. The server and
+// tracer provider are optional and are stopped after all registered hooks.
+func NewShutdownCoordinator(
+	config ShutdownConfig,
+	status *Status,
+	server *OperationalServer,
+	tracerProvider *sdktrace.TracerProvider,
+) *ShutdownCoordinator {
+	return &ShutdownCoordinator{
+		config:         config,
+		status:         status,
+		server:         server,
+		tracerProvider: tracerProvider,
+	}
+}
+
+// AddHook registers a named hook to run during shutdown
+func (c *ShutdownCoordinator) AddHook(name string, hook ShutdownHook, opts HookOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, shutdownHook{name: name, hook: hook, opts: opts})
+}
+
+// Wait blocks until SIGTERM or SIGINT is received or ctx is done, then runs Shutdown
+func (c *ShutdownCoordinator) Wait(ctx context.Context) error {
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	<-signalCtx.Done()
+	logging.GetLogger("shutdown").Info("shutdown requested")
+
+	return c.Shutdown(context.WithoutCancel(ctx))
+}
+
+// Shutdown marks the service as not ready, waits for the drain period, runs the
+// registered hooks and finally stops the operational server, flushes the tracer
+// provider and flushes the log sinks created by logging.ConfigureLogging. The
+// returned error joins every failure. Only the first call does any work; later
+// calls return the same result.
+func (c *ShutdownCoordinator) Shutdown(ctx context.Context) error {
+	c.once.Do(func() {
+		c.err = c.shutdown(ctx)
+	})
+	return c.err
+}
+
+func (c *ShutdownCoordinator) shutdown(ctx context.Context) error {
+	logger := logging.GetLogger("shutdown")
+
+	c.status.SetReady(false)
+	if c.config.DrainPeriod > 0 {
+		logger.Info("draining before shutdown", slog.Duration("drain_period", c.config.DrainPeriod))
+		timer := time.NewTimer(c.config.DrainPeriod)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	c.mu.Lock()
+	hooks := make([]shutdownHook, len(c.hooks))
+	copy(hooks, c.hooks)
+	c.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].opts.Order < hooks[j].opts.Order
+	})
+
+	var errs []error
+	for _, h := range hooks {
+		if err := c.runHook(ctx, h); err != nil {
+			logger.Error("shutdown hook failed", slog.String("hook", h.name), slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("shutdown hook %q: %w", h.name, err))
+		}
+	}
+
+	if c.server != nil {
+		if err := c.server.Stop(ctx); err != nil {
+			logger.Error("failed to stop operational server", slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("failed to stop operational server: %w", err))
+		}
+	}
+	if c.tracerProvider != nil {
+		if err := c.tracerProvider.Shutdown(ctx); err != nil {
+			logger.Error("failed to shut down tracer provider", slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("failed to shut down tracer provider: %w", err))
+		}
+	}
+	if err := logging.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush log sinks: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// runHook runs a single hook under its timeout, giving up on hooks that ignore cancellation
+func (c *ShutdownCoordinator) runHook(ctx context.Context, h shutdownHook) error {
+	timeout := h.opts.Timeout
+	if timeout <= 0 {
+		timeout = c.config.HookTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.hook(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,118 @@
+package operational
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/corruptmane/corrupt-o11y-go/metadata"
+	"github.com/corruptmane/corrupt-o11y-go/metrics"
+)
+
+func TestShutdownRunsHooksInOrder(t *testing.T) {
+	status := NewStatus()
+	status.SetReady(true)
+
+	coordinator := NewShutdownCoordinator(ShutdownConfig{HookTimeout: time.Second}, status, nil, nil)
+
+	var calls []string
+	record := func(name string) ShutdownHook {
+		return func(ctx context.Context) error {
+			if status.IsReady() {
+				t.Errorf("Expected status to not be ready when hook %s runs", name)
+			}
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	coordinator.AddHook("last", record("last"), HookOptions{Order: 10})
+	coordinator.AddHook("first", record("first"), HookOptions{Order: -1})
+	coordinator.AddHook("middle-a", record("middle-a"), HookOptions{})
+	coordinator.AddHook("middle-b", record("middle-b"), HookOptions{})
+
+	if err := coordinator.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"first", "middle-a", "middle-b", "last"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected hooks to run as %v, got %v", expected, calls)
+	}
+}
+
+func TestShutdownReportsHookFailures(t *testing.T) {
+	coordinator := NewShutdownCoordinator(ShutdownConfig{HookTimeout: time.Second}, NewStatus(), nil, nil)
+
+	errBoom := errors.New("boom")
+	ran := false
+	coordinator.AddHook("failing", func(ctx context.Context) error { return errBoom }, HookOptions{})
+	coordinator.AddHook("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}, HookOptions{Timeout: 10 * time.Millisecond})
+	coordinator.AddHook("after", func(ctx context.Context) error {
+		ran = true
+		return nil
+	}, HookOptions{})
+
+	err := coordinator.Shutdown(context.Background())
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Expected error to wrap hook failure, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to report hook timeout, got %v", err)
+	}
+	if !ran {
+		t.Error("Expected hooks after a failing hook to still run")
+	}
+
+	// Only the first call runs the shutdown sequence
+	if again := coordinator.Shutdown(context.Background()); again != err {
+		t.Errorf("Expected second Shutdown to return the first result, got %v", again)
+	}
+}
+
+func TestShutdownDrainsBeforeHooks(t *testing.T) {
+	coordinator := NewShutdownCoordinator(ShutdownConfig{DrainPeriod: 50 * time.Millisecond}, NewStatus(), nil, nil)
+
+	start := time.Now()
+	var elapsed time.Duration
+	coordinator.AddHook("measure", func(ctx context.Context) error {
+		elapsed = time.Since(start)
+		return nil
+	}, HookOptions{})
+
+	coordinator.Shutdown(context.Background())
+
+	if elapsed < 50*time.Millisecond {
+		t.Errorf("Expected hooks to run after the drain period, ran after %s", elapsed)
+	}
+}
+
+func TestShutdownStopsServer(t *testing.T) {
+	config := OperationalServerConfig{
+		Host: "127.0.0.1",
+		Port: 0,
+	}
+
+	status := NewStatus()
+	server := NewOperationalServer(config, metadata.ServiceInfo{}, status, metrics.NewMetricsCollector())
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+
+	coordinator := NewShutdownCoordinator(ShutdownConfig{}, status, server, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := coordinator.Wait(ctx); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if _, err := http.Get(server.ServerURL() + "/health"); err == nil {
+		t.Error("Expected operational server to be stopped after shutdown")
+	}
+}
@@ -1,13 +1,19 @@
 package operational
 
 import (
+	"context"
 	"sync/atomic"
 )
 
 // Status provides thread-safe service status tracking
 type Status struct {
-	ready atomic.Bool
-	alive atomic.Bool
+	ready   atomic.Bool
+	alive   atomic.Bool
+	started atomic.Bool
+
+	readinessChecks checkRegistry
+	livenessChecks  checkRegistry
+	startupChecks   checkRegistry
 }
 
 // NewStatus creates a new Status instance
@@ -27,6 +33,11 @@ func (s *Status) IsAlive() bool {
 	return s.alive.Load()
 }
 
+// IsStarted returns whether the service has finished starting up
+func (s *Status) IsStarted() bool {
+	return s.started.Load()
+}
+
 // SetReady sets the service ready status
 func (s *Status) SetReady(ready bool) {
 	s.ready.Store(ready)
@@ -36,3 +47,44 @@ func (s *Status) SetReady(ready bool) {
 func (s *Status) SetAlive(alive bool) {
 	s.alive.Store(alive)
 }
+
+// SetStarted sets the service started status
+func (s *Status) SetStarted(started bool) {
+	s.started.Store(started)
+}
+
+// AddReadinessCheck registers a named check that must pass for the service to be ready.
+// Registering a check with an existing name replaces it.
+func (s *Status) AddReadinessCheck(name string, fn CheckFunc, opts CheckOptions) {
+	s.readinessChecks.add(name, fn, opts)
+}
+
+// AddLivenessCheck registers a named check that must pass for the service to be alive.
+// Registering a check with an existing name replaces it.
+func (s *Status) AddLivenessCheck(name string, fn CheckFunc, opts CheckOptions) {
+	s.livenessChecks.add(name, fn, opts)
+}
+
+// AddStartupCheck registers a named check that must pass for the service to be started.
+// Registering a check with an existing name replaces it.
+func (s *Status) AddStartupCheck(name string, fn CheckFunc, opts CheckOptions) {
+	s.startupChecks.add(name, fn, opts)
+}
+
+// Readiness runs all readiness checks except the excluded ones. The report is
+// healthy only when the service has been marked ready and every check passes.
+func (s *Status) Readiness(ctx context.Context, exclude ...string) CheckReport {
+	return newCheckReport(s.IsReady(), s.readinessChecks.run(ctx, exclude...))
+}
+
+// Liveness runs all liveness checks except the excluded ones. The report is
+// healthy only when the service is marked alive and every check passes.
+func (s *Status) Liveness(ctx context.Context, exclude ...string) CheckReport {
+	return newCheckReport(s.IsAlive(), s.livenessChecks.run(ctx, exclude...))
+}
+
+// Startup runs all startup checks except the excluded ones. The report is
+// healthy only when the service has been marked started and every check passes.
+func (s *Status) Startup(ctx context.Context, exclude ...string) CheckReport {
+	return newCheckReport(s.IsStarted(), s.startupChecks.run(ctx, exclude...))
+}
@@ -1,6 +1,8 @@
 package operational
 
 import (
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -43,3 +45,58 @@ func TestSetAlive(t *testing.T) {
 		t.Error("Expected status to be alive after SetAlive(true)")
 	}
 }
+
+func TestReadinessChecks(t *testing.T) {
+	status := NewStatus()
+	status.SetReady(true)
+
+	var dbErr error
+	status.AddReadinessCheck("db", func(ctx context.Context) error { return dbErr }, CheckOptions{})
+
+	if !status.Readiness(context.Background()).Healthy {
+		t.Error("Expected readiness to be healthy when all checks pass")
+	}
+
+	dbErr = errors.New("connection refused")
+	report := status.Readiness(context.Background())
+	if report.Healthy {
+		t.Error("Expected readiness to be unhealthy when a check fails")
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Name != "db" {
+		t.Errorf("Expected report to contain the db check, got %+v", report.Checks)
+	}
+
+	// The manual flag overrides passing checks
+	dbErr = nil
+	status.SetReady(false)
+	if status.Readiness(context.Background()).Healthy {
+		t.Error("Expected readiness to be unhealthy after SetReady(false)")
+	}
+}
+
+func TestLivenessChecks(t *testing.T) {
+	status := NewStatus()
+	status.AddLivenessCheck("deadlock", func(ctx context.Context) error { return errors.New("stuck") }, CheckOptions{})
+
+	if status.Liveness(context.Background()).Healthy {
+		t.Error("Expected liveness to be unhealthy when a check fails")
+	}
+}
+
+func TestStartup(t *testing.T) {
+	status := NewStatus()
+
+	if status.IsStarted() {
+		t.Error("Expected new status to not be started")
+	}
+
+	status.SetStarted(true)
+	status.AddStartupCheck("migrations", func(ctx context.Context) error { return errors.New("pending") }, CheckOptions{})
+
+	if status.Startup(context.Background()).Healthy {
+		t.Error("Expected startup to be unhealthy when a check fails")
+	}
+	if !status.Startup(context.Background(), "migrations").Healthy {
+		t.Error("Expected startup to be healthy when the failing check is excluded")
+	}
+}
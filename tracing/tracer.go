@@ -20,14 +20,9 @@ import (
 
 // ConfigureTracing configures OpenTelemetry tracing
 func ConfigureTracing(ctx context.Context, config TracingConfig, serviceName, serviceVersion string) (*trace.TracerProvider, error) {
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(serviceName),
-			semconv.ServiceVersionKey.String(serviceVersion),
-		),
-	)
+	res, err := NewResource(ctx, serviceName, serviceVersion)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
 	var exporter trace.SpanExporter
@@ -59,12 +54,14 @@ func ConfigureTracing(ctx context.Context, config TracingConfig, serviceName, se
 		if err != nil {
 			return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
 		}
-		exporter, err = otlptracegrpc.New(ctx,
+		grpcExporter, err := otlptracegrpc.New(ctx,
 			otlptracegrpc.WithGRPCConn(conn),
 		)
 		if err != nil {
+			_ = conn.Close()
 			return nil, fmt.Errorf("failed to create GRPC exporter: %w", err)
 		}
+		exporter = &connClosingExporter{SpanExporter: grpcExporter, conn: conn}
 	default:
 		return nil, fmt.Errorf("unsupported export type: %s", config.ExportType)
 	}
@@ -80,7 +77,32 @@ func ConfigureTracing(ctx context.Context, config TracingConfig, serviceName, se
 	return tracerProvider, nil
 }
 
+// NewResource creates the OpenTelemetry resource describing the service
+func NewResource(ctx context.Context, serviceName, serviceVersion string) (*resource.Resource, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(serviceVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	return res, nil
+}
+
 // GetTracer returns an OpenTelemetry tracer
 func GetTracer(name string) oteltrace.Tracer {
 	return otel.Tracer(name)
 }
+
+// connClosingExporter closes the gRPC connection it was created with once the
+// wrapped exporter has been shut down, since the exporter does not own it
+type connClosingExporter struct {
+	trace.SpanExporter
+	conn *grpc.ClientConn
+}
+
+func (e *connClosingExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.conn.Close())
+}